@@ -0,0 +1,213 @@
+// Package basen 任意字母表的进制编码：Base62、Base58、Base36、Crockford Base32 以及自定义字母表
+package basen
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	// Base62 字母表，数字 + 大写 + 小写
+	Base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	// Base58 字母表(Bitcoin)，移除 0OIl
+	Base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	// Base36 字母表，数字 + 小写
+	Base36Alphabet = "0123456789abcdefghijklmnopqrstuvwxyz"
+)
+
+var (
+	// Base62 Base62 编码，适用于短链接
+	Base62 = MustNewEncoding(Base62Alphabet)
+	// Base58 Base58 编码(Bitcoin 字母表)
+	Base58 = MustNewEncoding(Base58Alphabet)
+	// Base36 Base36 编码，解码不区分大小写
+	Base36 = MustNewEncoding(Base36Alphabet)
+)
+
+// Encoder 字节与 uint64 的编解码
+type Encoder interface {
+	Encode(src []byte) string
+	Decode(s string) ([]byte, error)
+	EncodeUint64(n uint64) string
+	DecodeUint64(s string) (uint64, error)
+}
+
+// Encoding 以字母表长度为进制的编码，可安全地并发使用
+//
+// 字节按大整数编码，前导零字节编码为同样个数的首字符，与 Base58 的通行实现一致
+type Encoding struct {
+	alphabet string
+	decode   [256]int16
+}
+
+// NewEncoding 使用自定义字母表创建编码，字母表至少 2 个不重复的 ASCII 字符
+//
+// 字母表中的字母只有一种大小写时(如 Base36)，解码时兼容另一种大小写；
+// 同时包含大小写字母时(如 Base58、Base62)严格区分大小写
+func NewEncoding(alphabet string) (*Encoding, error) {
+	if len(alphabet) < 2 {
+		return nil, errors.New("basen: alphabet must contain at least 2 characters")
+	}
+
+	enc := &Encoding{alphabet: alphabet}
+	for i := range enc.decode {
+		enc.decode[i] = -1
+	}
+	for i := 0; i < len(alphabet); i++ {
+		c := alphabet[i]
+		if c >= 0x80 {
+			return nil, fmt.Errorf("basen: invalid alphabet character %q", c)
+		}
+		if enc.decode[c] >= 0 {
+			return nil, fmt.Errorf("basen: duplicate alphabet character %q", c)
+		}
+		enc.decode[c] = int16(i)
+	}
+	if !mixedCase(alphabet) {
+		for i := 0; i < len(alphabet); i++ {
+			if other := swapCase(alphabet[i]); other != alphabet[i] {
+				enc.decode[other] = int16(i)
+			}
+		}
+	}
+	return enc, nil
+}
+
+// MustNewEncoding 同 NewEncoding，字母表无效时 panic
+func MustNewEncoding(alphabet string) *Encoding {
+	enc, err := NewEncoding(alphabet)
+	if err != nil {
+		panic(err)
+	}
+	return enc
+}
+
+// 字母表是否同时包含大写与小写字母
+func mixedCase(alphabet string) bool {
+	var upper, lower bool
+	for i := 0; i < len(alphabet); i++ {
+		c := alphabet[i]
+		upper = upper || (c >= 'A' && c <= 'Z')
+		lower = lower || (c >= 'a' && c <= 'z')
+	}
+	return upper && lower
+}
+
+func swapCase(c byte) byte {
+	switch {
+	case c >= 'a' && c <= 'z':
+		return c - 'a' + 'A'
+	case c >= 'A' && c <= 'Z':
+		return c - 'A' + 'a'
+	}
+	return c
+}
+
+// Alphabet 返回字母表
+func (enc *Encoding) Alphabet() string {
+	return enc.alphabet
+}
+
+// Encode 编码字节
+func (enc *Encoding) Encode(src []byte) string {
+	base := uint32(len(enc.alphabet))
+	zeros := 0
+	for zeros < len(src) && src[zeros] == 0 {
+		zeros++
+	}
+
+	// 小端存储的 base 进制数字
+	digits := make([]byte, 0, len(src)*138/100+1)
+	for _, b := range src[zeros:] {
+		carry := uint32(b)
+		for j := range digits {
+			carry += uint32(digits[j]) << 8
+			digits[j] = byte(carry % base)
+			carry /= base
+		}
+		for carry > 0 {
+			digits = append(digits, byte(carry%base))
+			carry /= base
+		}
+	}
+
+	out := make([]byte, zeros+len(digits))
+	for i := 0; i < zeros; i++ {
+		out[i] = enc.alphabet[0]
+	}
+	for i, d := range digits {
+		out[len(out)-1-i] = enc.alphabet[d]
+	}
+	return string(out)
+}
+
+// Decode 解码 Encode 的结果
+func (enc *Encoding) Decode(s string) ([]byte, error) {
+	base := uint32(len(enc.alphabet))
+	zeros := 0
+	for zeros < len(s) && enc.decode[s[zeros]] == 0 {
+		zeros++
+	}
+
+	// 小端存储的字节
+	bytes := make([]byte, 0, len(s))
+	for i := zeros; i < len(s); i++ {
+		v := enc.decode[s[i]]
+		if v < 0 {
+			return nil, fmt.Errorf("basen: invalid character %q at %d", s[i], i)
+		}
+		carry := uint32(v)
+		for j := range bytes {
+			carry += uint32(bytes[j]) * base
+			bytes[j] = byte(carry)
+			carry >>= 8
+		}
+		for carry > 0 {
+			bytes = append(bytes, byte(carry))
+			carry >>= 8
+		}
+	}
+
+	out := make([]byte, zeros+len(bytes))
+	for i, b := range bytes {
+		out[len(out)-1-i] = b
+	}
+	return out, nil
+}
+
+// EncodeUint64 编码数字，0 编码为字母表首字符
+func (enc *Encoding) EncodeUint64(n uint64) string {
+	base := uint64(len(enc.alphabet))
+	var buf [64]byte
+	i := len(buf)
+	for {
+		i--
+		buf[i] = enc.alphabet[n%base]
+		n /= base
+		if n == 0 {
+			break
+		}
+	}
+	return string(buf[i:])
+}
+
+// DecodeUint64 解码 EncodeUint64 的结果，溢出时返回错误
+func (enc *Encoding) DecodeUint64(s string) (uint64, error) {
+	if s == "" {
+		return 0, errors.New("basen: empty string")
+	}
+	base := uint64(len(enc.alphabet))
+	var n uint64
+	for i := 0; i < len(s); i++ {
+		v := enc.decode[s[i]]
+		if v < 0 {
+			return 0, fmt.Errorf("basen: invalid character %q at %d", s[i], i)
+		}
+		if n > (math.MaxUint64-uint64(v))/base {
+			return 0, fmt.Errorf("basen: %q overflows uint64", s)
+		}
+		n = n*base + uint64(v)
+	}
+	return n, nil
+}
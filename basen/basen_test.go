@@ -0,0 +1,101 @@
+package basen
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/arnoluo/gu/random"
+	"github.com/arnoluo/gu/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBase58(t *testing.T) {
+	assert.Equal(t, "2NEpo7TZRRrLZSi2U", Base58.Encode([]byte("Hello World!")))
+	assert.Equal(t, "11233QC4", Base58.Encode([]byte{0, 0, 0x28, 0x7f, 0xb4, 0xcd}))
+	assert.Equal(t, "", Base58.Encode(nil))
+
+	b, err := Base58.Decode("11233QC4")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0, 0, 0x28, 0x7f, 0xb4, 0xcd}, b)
+
+	_, err = Base58.Decode("0OIl")
+	assert.NotNil(t, err)
+	// 字母表同时包含大小写时不兼容大小写，排除的字符不可解码
+	for _, c := range []string{"0", "O", "I", "l"} {
+		_, err = Base58.Decode(c)
+		assert.NotNil(t, err, c)
+		_, err = Base58.DecodeUint64(c)
+		assert.NotNil(t, err, c)
+	}
+}
+
+func TestCaseFolding(t *testing.T) {
+	// Base62 区分大小写
+	a, _ := Base62.DecodeUint64("a")
+	A, _ := Base62.DecodeUint64("A")
+	assert.Equal(t, uint64(36), a)
+	assert.Equal(t, uint64(10), A)
+
+	// 只有小写字母的 Base36 兼容大写
+	n, err := Base36.DecodeUint64("Zz")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(35*36+35), n)
+}
+
+func TestEncodingRoundTrip(t *testing.T) {
+	r := random.New(random.NewSeeded(1))
+	for _, enc := range []*Encoding{Base62, Base58, Base36, MustNewEncoding(types.CHARS)} {
+		for i := 0; i < 100; i++ {
+			src := make([]byte, r.Intn(40))
+			r.Read(src)
+			if i%10 == 0 && len(src) > 2 {
+				src[0], src[1] = 0, 0
+			}
+			got, err := enc.Decode(enc.Encode(src))
+			assert.Nil(t, err)
+			assert.Equal(t, src, got)
+
+			n := r.Uint64()
+			m, err := enc.DecodeUint64(enc.EncodeUint64(n))
+			assert.Nil(t, err)
+			assert.Equal(t, n, m)
+		}
+	}
+}
+
+func TestUint64(t *testing.T) {
+	for _, n := range []uint64{0, 1, 35, 36, 123456789, math.MaxUint64} {
+		assert.Equal(t, strconv.FormatUint(n, 36), Base36.EncodeUint64(n))
+	}
+	assert.Equal(t, "0", Base62.EncodeUint64(0))
+	assert.Equal(t, "LygHa16AHYF", Base62.EncodeUint64(math.MaxUint64))
+
+	// 字母表只有一种大小写时，解码兼容另一种
+	n, err := Base36.DecodeUint64("ZZ")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(36*36-1), n)
+
+	_, err = Base36.DecodeUint64("")
+	assert.NotNil(t, err)
+	_, err = Base36.DecodeUint64("3w5e11264sgsg")
+	assert.NotNil(t, err)
+	_, err = Base62.DecodeUint64("a-b")
+	assert.NotNil(t, err)
+}
+
+func TestNewEncoding(t *testing.T) {
+	enc, err := NewEncoding("01")
+	assert.Nil(t, err)
+	assert.Equal(t, "1010", enc.EncodeUint64(10))
+	assert.Equal(t, "01", enc.Alphabet())
+
+	for _, alphabet := range []string{"", "a", "aba", "abcé"} {
+		_, err := NewEncoding(alphabet)
+		assert.NotNil(t, err, alphabet)
+	}
+	assert.Panics(t, func() { MustNewEncoding("a") })
+
+	var _ Encoder = Base62
+	var _ Encoder = Crockford32
+}
@@ -0,0 +1,184 @@
+package basen
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// Crockford Base32 字母表，移除 ILOU
+	CrockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	// 校验符号额外使用的 5 个字符，与字母表共 37 个
+	crockfordCheckSymbols = "*~$=U"
+)
+
+var (
+	// Crockford32 Crockford Base32 编码
+	Crockford32 = &CrockfordEncoding{}
+	// Crockford32Check 末尾附加 mod 37 校验符号的 Crockford Base32 编码
+	Crockford32Check = &CrockfordEncoding{checksum: true}
+)
+
+// crockfordDecode 解码表，兼容小写以及 I/L => 1、O => 0
+var crockfordDecode [256]int8
+
+func init() {
+	for i := range crockfordDecode {
+		crockfordDecode[i] = -1
+	}
+	for i := 0; i < len(CrockfordAlphabet); i++ {
+		c := CrockfordAlphabet[i]
+		crockfordDecode[c] = int8(i)
+		crockfordDecode[swapCase(c)] = int8(i)
+	}
+	for _, c := range "IiLl" {
+		crockfordDecode[c] = 1
+	}
+	crockfordDecode['O'], crockfordDecode['o'] = 0, 0
+}
+
+// CrockfordEncoding Crockford Base32 编码，字节按每 5 位一个字符编码(不补齐)，数字按 32 进制编码
+//
+// 解码时不区分大小写，I/L 视为 1，O 视为 0，忽略连字符
+type CrockfordEncoding struct {
+	checksum bool
+}
+
+// Encode 编码字节
+func (enc *CrockfordEncoding) Encode(src []byte) string {
+	out := make([]byte, 0, (len(src)*8+4)/5+1)
+	var buf uint32
+	bits := 0
+	for _, b := range src {
+		buf = buf<<8 | uint32(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			out = append(out, CrockfordAlphabet[buf>>uint(bits)&31])
+		}
+	}
+	if bits > 0 {
+		out = append(out, CrockfordAlphabet[buf<<uint(5-bits)&31])
+	}
+	return string(enc.appendCheck(out))
+}
+
+// Decode 解码 Encode 的结果，校验失败或末尾多余的位不为 0 时返回错误
+func (enc *CrockfordEncoding) Decode(s string) ([]byte, error) {
+	values, err := enc.values(s)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(values)*5/8)
+	var buf uint32
+	bits := 0
+	for _, v := range values {
+		buf = buf<<5 | uint32(v)
+		bits += 5
+		if bits >= 8 {
+			bits -= 8
+			out = append(out, byte(buf>>uint(bits)))
+		}
+	}
+	if bits >= 5 || buf&(1<<uint(bits)-1) != 0 {
+		return nil, fmt.Errorf("basen: invalid crockford32 %q", s)
+	}
+	return out, nil
+}
+
+// EncodeUint64 编码数字
+func (enc *CrockfordEncoding) EncodeUint64(n uint64) string {
+	var buf [14]byte
+	i := 13
+	for {
+		i--
+		buf[i] = CrockfordAlphabet[n&31]
+		n >>= 5
+		if n == 0 {
+			break
+		}
+	}
+	return string(enc.appendCheck(buf[i:13]))
+}
+
+// DecodeUint64 解码 EncodeUint64 的结果，校验失败或溢出时返回错误
+func (enc *CrockfordEncoding) DecodeUint64(s string) (uint64, error) {
+	values, err := enc.values(s)
+	if err != nil {
+		return 0, err
+	}
+	if len(values) == 0 {
+		return 0, errors.New("basen: empty string")
+	}
+	var n uint64
+	for _, v := range values {
+		if n>>59 != 0 {
+			return 0, fmt.Errorf("basen: %q overflows uint64", s)
+		}
+		n = n<<5 | uint64(v)
+	}
+	return n, nil
+}
+
+// 附加校验符号，值为全部符号组成的 32 进制数 mod 37
+func (enc *CrockfordEncoding) appendCheck(out []byte) []byte {
+	if !enc.checksum {
+		return out
+	}
+	return append(out, checkSymbol(out))
+}
+
+func checkSymbol(symbols []byte) byte {
+	var m int
+	for _, c := range symbols {
+		m = (m*32 + int(crockfordDecode[c])) % 37
+	}
+	if m < 32 {
+		return CrockfordAlphabet[m]
+	}
+	return crockfordCheckSymbols[m-32]
+}
+
+// 去除连字符并解析符号值，启用校验时验证并去除末尾的校验符号
+func (enc *CrockfordEncoding) values(s string) ([]int8, error) {
+	symbols := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] != '-' {
+			symbols = append(symbols, s[i])
+		}
+	}
+
+	var check byte
+	if enc.checksum {
+		if len(symbols) == 0 {
+			return nil, errors.New("basen: missing check symbol")
+		}
+		check = symbols[len(symbols)-1]
+		symbols = symbols[:len(symbols)-1]
+	}
+
+	values := make([]int8, len(symbols))
+	for i, c := range symbols {
+		values[i] = crockfordDecode[c]
+		if values[i] < 0 {
+			return nil, fmt.Errorf("basen: invalid character %q in %q", c, s)
+		}
+	}
+
+	if enc.checksum && normalizeCheck(check) != checkSymbol(symbols) {
+		return nil, fmt.Errorf("basen: checksum mismatch in %q", s)
+	}
+	return values, nil
+}
+
+// 校验符号中的字母统一为大写，并按解码规则归一化 I/L/O
+func normalizeCheck(c byte) byte {
+	if v := crockfordDecode[c]; v >= 0 {
+		return CrockfordAlphabet[v]
+	}
+	if c == 'u' {
+		return 'U'
+	}
+	return c
+}
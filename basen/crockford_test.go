@@ -0,0 +1,68 @@
+package basen
+
+import (
+	"math"
+	"testing"
+
+	"github.com/arnoluo/gu/random"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCrockford32(t *testing.T) {
+	assert.Equal(t, "CSQPYRK1E8", Crockford32.Encode([]byte("foobar")))
+	assert.Equal(t, "", Crockford32.Encode(nil))
+
+	b, err := Crockford32.Decode("csqp-yrk1-e8")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("foobar"), b)
+
+	// 末尾多余的位不为 0
+	_, err = Crockford32.Decode("CSQPYRK1E9")
+	assert.NotNil(t, err)
+	_, err = Crockford32.Decode("CSQPYRK1E8U")
+	assert.NotNil(t, err)
+
+	assert.Equal(t, "16J", Crockford32.EncodeUint64(1234))
+	n, err := Crockford32.DecodeUint64("16j")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1234), n)
+	n, _ = Crockford32.DecodeUint64("OIL")
+	assert.Equal(t, uint64(33), n)
+	assert.Equal(t, "FZZZZZZZZZZZZ", Crockford32.EncodeUint64(math.MaxUint64))
+	_, err = Crockford32.DecodeUint64("G000000000000")
+	assert.NotNil(t, err)
+	_, err = Crockford32.DecodeUint64("")
+	assert.NotNil(t, err)
+}
+
+func TestCrockford32Check(t *testing.T) {
+	// 1234 mod 37 = 13
+	assert.Equal(t, "16JD", Crockford32Check.EncodeUint64(1234))
+	n, err := Crockford32Check.DecodeUint64("16jd")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1234), n)
+
+	_, err = Crockford32Check.DecodeUint64("16JE")
+	assert.NotNil(t, err)
+	_, err = Crockford32Check.DecodeUint64("17JD")
+	assert.NotNil(t, err)
+	_, err = Crockford32Check.DecodeUint64("")
+	assert.NotNil(t, err)
+
+	// 扩展校验符号 *~$=U
+	assert.Equal(t, "10*", Crockford32Check.EncodeUint64(32))
+	n, err = Crockford32Check.DecodeUint64("10*")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(32), n)
+	assert.Equal(t, "14U", Crockford32Check.EncodeUint64(36))
+
+	r := random.New(random.NewSeeded(1))
+	for i := 0; i < 100; i++ {
+		src := make([]byte, r.Intn(30))
+		r.Read(src)
+		s := Crockford32Check.Encode(src)
+		got, err := Crockford32Check.Decode(s)
+		assert.Nil(t, err)
+		assert.Equal(t, src, got)
+	}
+}
@@ -0,0 +1,218 @@
+// Package calendar 工作日历，支持周末配置、节假日与调休工作日、工作日推算以及工作时段内的 SLA 计算
+//
+// 日期均按传入时间自身的时区计算。
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 连续查找的最大天数，超出时认为日历中不存在工作日
+const maxSearchDays = 3660
+
+type date struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func dateOf(t time.Time) date {
+	y, m, d := t.Date()
+	return date{y, m, d}
+}
+
+func (d date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.year, d.month, d.day)
+}
+
+// Calendar 工作日历，可安全地并发使用
+type Calendar struct {
+	mu       sync.RWMutex
+	weekend  [7]bool
+	holidays map[date]string
+	workdays map[date]string
+	hours    []window
+}
+
+// New 创建工作日历，默认周六、周日为周末，工作时段为 09:00-18:00
+func New() *Calendar {
+	c := &Calendar{
+		holidays: map[date]string{},
+		workdays: map[date]string{},
+		hours:    []window{{9 * 60, 18 * 60}},
+	}
+	c.weekend[time.Saturday] = true
+	c.weekend[time.Sunday] = true
+	return c
+}
+
+// SetWeekend 设置周末，不传参数表示没有周末，忽略 time.Sunday ~ time.Saturday 以外的值
+func (c *Calendar) SetWeekend(days ...time.Weekday) *Calendar {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.weekend = [7]bool{}
+	for _, d := range days {
+		if d >= time.Sunday && d <= time.Saturday {
+			c.weekend[d] = true
+		}
+	}
+	return c
+}
+
+// AddHoliday 添加节假日，dates 为 "2024-10-01" 或区间 "2024-10-01~2024-10-07"
+func (c *Calendar) AddHoliday(dates, name string) error {
+	return c.add(c.holidays, dates, name)
+}
+
+// AddWorkday 添加调休工作日(如周末补班)，dates 格式同 AddHoliday
+func (c *Calendar) AddWorkday(dates, name string) error {
+	return c.add(c.workdays, dates, name)
+}
+
+func (c *Calendar) add(m map[date]string, dates, name string) error {
+	from, to, err := parseDates(dates)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		key := dateOf(d)
+		// 同一日期只保留最后一次设置
+		delete(c.holidays, key)
+		delete(c.workdays, key)
+		m[key] = name
+	}
+	return nil
+}
+
+func parseDates(dates string) (from, to time.Time, err error) {
+	parts := strings.SplitN(dates, "~", 2)
+	from, err = time.Parse("2006-01-02", strings.TrimSpace(parts[0]))
+	if err != nil {
+		return from, to, fmt.Errorf("calendar: invalid date %q", dates)
+	}
+	to = from
+	if len(parts) == 2 {
+		to, err = time.Parse("2006-01-02", strings.TrimSpace(parts[1]))
+		if err != nil || to.Before(from) {
+			return from, to, fmt.Errorf("calendar: invalid date range %q", dates)
+		}
+	}
+	return from, to, nil
+}
+
+// Holiday 返回 t 所在日期的节假日名称
+func (c *Calendar) Holiday(t time.Time) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	name, ok := c.holidays[dateOf(t)]
+	return name, ok
+}
+
+// Holidays 返回 [from, to] 日期区间内的节假日，键为 Ymd 日期
+func (c *Calendar) Holidays(from, to time.Time) map[string]string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	res := map[string]string{}
+	lo, hi := dateOf(from).String(), dateOf(to).String()
+	for d, name := range c.holidays {
+		if s := d.String(); s >= lo && s <= hi {
+			res[s] = name
+		}
+	}
+	return res
+}
+
+// IsWorkday 判断 t 所在日期是否为工作日，调休工作日优先于节假日与周末
+func (c *Calendar) IsWorkday(t time.Time) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.isWorkday(t)
+}
+
+func (c *Calendar) isWorkday(t time.Time) bool {
+	d := dateOf(t)
+	if _, ok := c.workdays[d]; ok {
+		return true
+	}
+	if _, ok := c.holidays[d]; ok {
+		return false
+	}
+	return !c.weekend[t.Weekday()]
+}
+
+// NextWorkday 返回 t 之后的第一个工作日(保留时分秒)，不存在时返回零值
+func (c *Calendar) NextWorkday(t time.Time) time.Time {
+	return c.AddWorkdays(t, 1)
+}
+
+// PrevWorkday 返回 t 之前的最后一个工作日(保留时分秒)，不存在时返回零值
+func (c *Calendar) PrevWorkday(t time.Time) time.Time {
+	return c.AddWorkdays(t, -1)
+}
+
+// AddWorkdays 加 n 个工作日(保留时分秒)，n 为负数时向前推算，日历中不存在工作日时返回零值
+//
+// 如周四加 2 个工作日为下周一
+func (c *Calendar) AddWorkdays(t time.Time, n int) time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for idle := 0; n > 0; {
+		t = t.AddDate(0, 0, step)
+		if c.isWorkday(t) {
+			n--
+			idle = 0
+		} else if idle++; idle > maxSearchDays {
+			return time.Time{}
+		}
+	}
+	return t
+}
+
+// WorkdaysBetween 返回 [from, to) 日期区间内的工作日数，to 早于 from 时返回负数
+func (c *Calendar) WorkdaysBetween(from, to time.Time) int {
+	if dateOf(to).String() < dateOf(from).String() {
+		return -c.WorkdaysBetween(to, from)
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	y, m, d := from.Date()
+	day := time.Date(y, m, d, 12, 0, 0, 0, from.Location())
+	end := dateOf(to)
+	count := 0
+	for dateOf(day) != end {
+		if c.isWorkday(day) {
+			count++
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return count
+}
+
+// Workdays 返回 [from, to) 日期区间内的工作日日期(Ymd)
+func (c *Calendar) Workdays(from, to time.Time) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var days []string
+	y, m, d := from.Date()
+	day := time.Date(y, m, d, 12, 0, 0, 0, from.Location())
+	end := dateOf(to).String()
+	for s := dateOf(day).String(); s < end; s = dateOf(day).String() {
+		if c.isWorkday(day) {
+			days = append(days, s)
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return days
+}
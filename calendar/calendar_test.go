@@ -0,0 +1,132 @@
+package calendar
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const holidays2024 = `
+# 2024 春节、国庆
+weekend = sat, sun
+
+[holidays]
+2024-02-10~2024-02-17 春节
+2024-10-01~2024-10-07 国庆节
+
+[workdays]
+2024-02-04 春节调休
+2024-02-18 春节调休
+2024-09-29 国庆节调休
+2024-10-12 国庆节调休
+`
+
+func day(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 10, 30, 0, 0, time.UTC)
+}
+
+func newCalendar(t *testing.T) *Calendar {
+	c := New()
+	assert.Nil(t, c.Load(strings.NewReader(holidays2024)))
+	return c
+}
+
+func TestIsWorkday(t *testing.T) {
+	c := newCalendar(t)
+	assert.True(t, c.IsWorkday(day(2024, 2, 9)))
+	assert.False(t, c.IsWorkday(day(2024, 2, 10)))
+	assert.False(t, c.IsWorkday(day(2024, 2, 12)))
+	assert.True(t, c.IsWorkday(day(2024, 2, 4)))
+	assert.True(t, c.IsWorkday(day(2024, 2, 18)))
+	assert.False(t, c.IsWorkday(day(2024, 2, 24)))
+	assert.True(t, c.IsWorkday(day(2024, 2, 26)))
+
+	name, ok := c.Holiday(day(2024, 10, 3))
+	assert.True(t, ok)
+	assert.Equal(t, "国庆节", name)
+	_, ok = c.Holiday(day(2024, 10, 12))
+	assert.False(t, ok)
+
+	assert.Equal(t, map[string]string{"2024-02-16": "春节", "2024-02-17": "春节"}, c.Holidays(day(2024, 2, 16), day(2024, 2, 20)))
+
+	// 后设置的日期覆盖之前的设置
+	assert.Nil(t, c.AddHoliday("2024-10-12", "调整"))
+	assert.False(t, c.IsWorkday(day(2024, 10, 12)))
+
+	// 中东地区周末为周五、周六
+	c.SetWeekend(time.Friday, time.Saturday)
+	assert.False(t, c.IsWorkday(day(2024, 3, 1)))
+	assert.True(t, c.IsWorkday(day(2024, 3, 3)))
+
+	// 忽略无效的星期
+	c.SetWeekend(-1, 7, time.Sunday)
+	assert.True(t, c.IsWorkday(day(2024, 3, 2)))
+	assert.False(t, c.IsWorkday(day(2024, 3, 3)))
+	c.SetWeekend(time.Friday, time.Saturday)
+
+	assert.NotNil(t, c.AddHoliday("2024-13-01", ""))
+	assert.NotNil(t, c.AddHoliday("2024-10-07~2024-10-01", ""))
+	assert.NotNil(t, c.AddWorkday("tomorrow", ""))
+}
+
+func TestAddWorkdays(t *testing.T) {
+	c := newCalendar(t)
+	assert.Equal(t, day(2024, 2, 9), c.AddWorkdays(day(2024, 2, 8), 1))
+	assert.Equal(t, day(2024, 2, 19), c.AddWorkdays(day(2024, 2, 8), 3))
+	assert.Equal(t, day(2024, 2, 18), c.AddWorkdays(day(2024, 2, 10), 1))
+	assert.Equal(t, day(2024, 2, 8), c.AddWorkdays(day(2024, 2, 19), -3))
+	assert.Equal(t, day(2024, 2, 8), c.AddWorkdays(day(2024, 2, 8), 0))
+	assert.Equal(t, day(2024, 3, 4), c.NextWorkday(day(2024, 3, 1)))
+	assert.Equal(t, day(2024, 9, 30), c.PrevWorkday(day(2024, 10, 8)))
+
+	assert.True(t, New().SetWeekend(0, 1, 2, 3, 4, 5, 6).AddWorkdays(day(2024, 1, 1), 1).IsZero())
+}
+
+func TestWorkdaysBetween(t *testing.T) {
+	c := newCalendar(t)
+	// 2024-02: 21 个平日 - 5 个春节平日 + 2 个调休
+	assert.Equal(t, 18, c.WorkdaysBetween(day(2024, 2, 1), day(2024, 3, 1)))
+	assert.Equal(t, -18, c.WorkdaysBetween(day(2024, 3, 1), day(2024, 2, 1)))
+	assert.Equal(t, 0, c.WorkdaysBetween(day(2024, 2, 1), day(2024, 2, 1)))
+	assert.Equal(t, 1, c.WorkdaysBetween(day(2024, 2, 1), day(2024, 2, 2)))
+	assert.Equal(t, []string{"2024-09-27", "2024-09-29", "2024-09-30", "2024-10-08"}, c.Workdays(day(2024, 9, 27), day(2024, 10, 9)))
+}
+
+func TestLoad(t *testing.T) {
+	c := New()
+	err := c.Load(strings.NewReader("weekend = fri, 6\nhours = 13:00-18:00, 08:00-12:00\n[holidays]\n2024-05-01\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"08:00-12:00", "13:00-18:00"}, c.Hours())
+	assert.False(t, c.IsWorkday(day(2024, 3, 1)))
+	assert.True(t, c.IsWorkday(day(2024, 3, 3)))
+	name, ok := c.Holiday(day(2024, 5, 1))
+	assert.True(t, ok)
+	assert.Equal(t, "", name)
+
+	for _, content := range []string{
+		"[unknown]\n",
+		"[holidays\n",
+		"weekend = funday\n",
+		"hours = 18:00-09:00\n",
+		"foo = bar\n",
+		"2024-01-01\n",
+		"[workdays]\n2024-02-30 调休\n",
+	} {
+		err := New().Load(strings.NewReader(content))
+		assert.NotNil(t, err, content)
+	}
+	err = New().Load(strings.NewReader("\n\n[holidays]\nbad\n"))
+	assert.Equal(t, `calendar: line 4: invalid date "bad"`, err.Error())
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "holidays.conf")
+	assert.Nil(t, os.WriteFile(path, []byte(holidays2024), 0644))
+	c = New()
+	assert.Nil(t, c.LoadFile(path))
+	assert.False(t, c.IsWorkday(day(2024, 10, 1)))
+	assert.NotNil(t, c.LoadFile(filepath.Join(dir, "missing.conf")))
+}
@@ -0,0 +1,220 @@
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 工作时段，单位为当天零点起的分钟数
+type window struct {
+	start, end int
+}
+
+func (w window) String() string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", w.start/60, w.start%60, w.end/60, w.end%60)
+}
+
+// SetHours 设置每个工作日的工作时段，如 SetHours("09:00-12:00", "13:00-18:00")，结束时间可为 24:00
+func (c *Calendar) SetHours(windows ...string) error {
+	if len(windows) == 0 {
+		return errors.New("calendar: no business hours")
+	}
+
+	hours := make([]window, 0, len(windows))
+	for _, s := range windows {
+		w, err := parseWindow(s)
+		if err != nil {
+			return err
+		}
+		hours = append(hours, w)
+	}
+	sort.Slice(hours, func(i, j int) bool { return hours[i].start < hours[j].start })
+	for i := 1; i < len(hours); i++ {
+		if hours[i].start < hours[i-1].end {
+			return fmt.Errorf("calendar: business hours %s and %s overlap", hours[i-1], hours[i])
+		}
+	}
+
+	c.mu.Lock()
+	c.hours = hours
+	c.mu.Unlock()
+	return nil
+}
+
+// Hours 返回工作时段，如 ["09:00-12:00", "13:00-18:00"]
+func (c *Calendar) Hours() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	res := make([]string, len(c.hours))
+	for i, w := range c.hours {
+		res[i] = w.String()
+	}
+	return res
+}
+
+func parseWindow(s string) (window, error) {
+	parts := strings.SplitN(s, "-", 2)
+	if len(parts) == 2 {
+		start, err1 := parseClock(parts[0])
+		end, err2 := parseClock(parts[1])
+		if err1 == nil && err2 == nil && start < end {
+			return window{start, end}, nil
+		}
+	}
+	return window{}, fmt.Errorf("calendar: invalid business hours %q", s)
+}
+
+// 解析 HH:MM 为分钟数，允许 24:00
+func parseClock(s string) (int, error) {
+	parts := strings.SplitN(strings.TrimSpace(s), ":", 2)
+	if len(parts) != 2 || len(parts[1]) != 2 {
+		return 0, fmt.Errorf("invalid clock %q", s)
+	}
+	h, err1 := strconv.Atoi(parts[0])
+	m, err2 := strconv.Atoi(parts[1])
+	if err1 != nil || err2 != nil || h < 0 || m < 0 || m > 59 || h > 24 || (h == 24 && m > 0) {
+		return 0, fmt.Errorf("invalid clock %q", s)
+	}
+	return h*60 + m, nil
+}
+
+// 返回 day 当天的工作时段，非工作日返回 nil
+func (c *Calendar) spans(day time.Time) [][2]time.Time {
+	if !c.isWorkday(day) {
+		return nil
+	}
+	y, m, d := day.Date()
+	loc := day.Location()
+	res := make([][2]time.Time, len(c.hours))
+	for i, w := range c.hours {
+		res[i] = [2]time.Time{
+			time.Date(y, m, d, w.start/60, w.start%60, 0, 0, loc),
+			time.Date(y, m, d, w.end/60, w.end%60, 0, 0, loc),
+		}
+	}
+	return res
+}
+
+func noon(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 12, 0, 0, 0, t.Location())
+}
+
+// IsWorkingTime 判断 t 是否处于工作日的工作时段内
+func (c *Calendar) IsWorkingTime(t time.Time) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, span := range c.spans(t) {
+		if !t.Before(span[0]) && t.Before(span[1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextWorkingTime 返回不早于 t 的最近工作时间，t 处于工作时段内时返回 t，不存在时返回零值
+func (c *Calendar) NextWorkingTime(t time.Time) time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	day := noon(t)
+	for i := 0; i <= maxSearchDays; i++ {
+		for _, span := range c.spans(day) {
+			if t.Before(span[1]) {
+				if t.Before(span[0]) {
+					return span[0]
+				}
+				return t
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return time.Time{}
+}
+
+// WorkingDuration 返回 from 到 to 之间的工作时长，to 早于 from 时返回负数
+func (c *Calendar) WorkingDuration(from, to time.Time) time.Duration {
+	if to.Before(from) {
+		return -c.WorkingDuration(to, from)
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var total time.Duration
+	end := noon(to.In(from.Location()))
+	for day := noon(from); !day.After(end); day = day.AddDate(0, 0, 1) {
+		for _, span := range c.spans(day) {
+			s, e := span[0], span[1]
+			if s.Before(from) {
+				s = from
+			}
+			if e.After(to) {
+				e = to
+			}
+			if s.Before(e) {
+				total += e.Sub(s)
+			}
+		}
+	}
+	return total
+}
+
+// AddWorkingDuration 在 t 上加 d 的工作时长，用于计算 SLA 截止时间，d 为负数时向前推算，不存在工作时段时返回零值
+//
+// 如周五 17:00 加 2h(工作时段 09:00-18:00)为下周一 10:00
+func (c *Calendar) AddWorkingDuration(t time.Time, d time.Duration) time.Time {
+	if d == 0 {
+		return t
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	day := noon(t)
+	for idle := 0; idle <= maxSearchDays; {
+		spans := c.spans(day)
+		if len(spans) == 0 {
+			idle++
+		} else {
+			idle = 0
+		}
+
+		if d > 0 {
+			for _, span := range spans {
+				s, e := span[0], span[1]
+				if s.Before(t) {
+					s = t
+				}
+				if !s.Before(e) {
+					continue
+				}
+				avail := e.Sub(s)
+				if d <= avail {
+					return s.Add(d)
+				}
+				d -= avail
+			}
+			day = day.AddDate(0, 0, 1)
+			continue
+		}
+
+		for i := len(spans) - 1; i >= 0; i-- {
+			s, e := spans[i][0], spans[i][1]
+			if e.After(t) {
+				e = t
+			}
+			if !s.Before(e) {
+				continue
+			}
+			avail := e.Sub(s)
+			if -d <= avail {
+				return e.Add(d)
+			}
+			d += avail
+		}
+		day = day.AddDate(0, 0, -1)
+	}
+	return time.Time{}
+}
@@ -0,0 +1,78 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func at(y int, m time.Month, d, h, mi int) time.Time {
+	return time.Date(y, m, d, h, mi, 0, 0, time.UTC)
+}
+
+func TestSetHours(t *testing.T) {
+	c := New()
+	assert.Equal(t, []string{"09:00-18:00"}, c.Hours())
+	assert.Nil(t, c.SetHours("13:00-18:00", "9:00-12:00"))
+	assert.Equal(t, []string{"09:00-12:00", "13:00-18:00"}, c.Hours())
+	assert.Nil(t, c.SetHours("00:00-24:00"))
+
+	for _, hours := range [][]string{
+		{}, {"09:00"}, {"09:00-09:00"}, {"18:00-09:00"}, {"09:00-25:00"}, {"09:60-10:00"},
+		{"9-18"}, {"09:00-12:00", "11:00-18:00"},
+	} {
+		assert.NotNil(t, c.SetHours(hours...), hours)
+	}
+	assert.Equal(t, []string{"00:00-24:00"}, c.Hours())
+}
+
+func TestWorkingTime(t *testing.T) {
+	c := newCalendar(t)
+	assert.Nil(t, c.SetHours("09:00-12:00", "13:00-18:00"))
+
+	assert.True(t, c.IsWorkingTime(at(2024, 3, 1, 9, 0)))
+	assert.False(t, c.IsWorkingTime(at(2024, 3, 1, 12, 30)))
+	assert.False(t, c.IsWorkingTime(at(2024, 3, 1, 18, 0)))
+	assert.False(t, c.IsWorkingTime(at(2024, 3, 2, 10, 0)))
+
+	assert.Equal(t, at(2024, 3, 1, 10, 0), c.NextWorkingTime(at(2024, 3, 1, 10, 0)))
+	assert.Equal(t, at(2024, 3, 1, 13, 0), c.NextWorkingTime(at(2024, 3, 1, 12, 0)))
+	assert.Equal(t, at(2024, 3, 4, 9, 0), c.NextWorkingTime(at(2024, 3, 1, 18, 0)))
+	assert.Equal(t, at(2024, 2, 18, 9, 0), c.NextWorkingTime(at(2024, 2, 9, 20, 0)))
+}
+
+func TestWorkingDuration(t *testing.T) {
+	c := newCalendar(t)
+	assert.Nil(t, c.SetHours("09:00-12:00", "13:00-18:00"))
+
+	assert.Equal(t, 2*time.Hour, c.WorkingDuration(at(2024, 3, 1, 11, 0), at(2024, 3, 1, 14, 0)))
+	assert.Equal(t, 8*time.Hour, c.WorkingDuration(at(2024, 3, 1, 0, 0), at(2024, 3, 2, 0, 0)))
+	// 跨周末
+	assert.Equal(t, 3*time.Hour, c.WorkingDuration(at(2024, 3, 1, 16, 0), at(2024, 3, 4, 10, 0)))
+	assert.Equal(t, -3*time.Hour, c.WorkingDuration(at(2024, 3, 4, 10, 0), at(2024, 3, 1, 16, 0)))
+	// 跨春节假期: 02-09 3h, 02-18 8h, 02-19 1h
+	assert.Equal(t, 12*time.Hour, c.WorkingDuration(at(2024, 2, 9, 15, 0), at(2024, 2, 19, 10, 0)))
+}
+
+func TestAddWorkingDuration(t *testing.T) {
+	c := newCalendar(t)
+	assert.Nil(t, c.SetHours("09:00-12:00", "13:00-18:00"))
+
+	assert.Equal(t, at(2024, 3, 1, 11, 0), c.AddWorkingDuration(at(2024, 3, 1, 10, 0), time.Hour))
+	assert.Equal(t, at(2024, 3, 1, 14, 0), c.AddWorkingDuration(at(2024, 3, 1, 10, 0), 3*time.Hour))
+	assert.Equal(t, at(2024, 3, 1, 18, 0), c.AddWorkingDuration(at(2024, 3, 1, 17, 0), time.Hour))
+	assert.Equal(t, at(2024, 3, 4, 10, 0), c.AddWorkingDuration(at(2024, 3, 1, 17, 0), 2*time.Hour))
+	// 非工作时间开始计时
+	assert.Equal(t, at(2024, 3, 4, 10, 0), c.AddWorkingDuration(at(2024, 3, 2, 15, 0), time.Hour))
+	// 3 个工作日的 SLA 跨春节
+	assert.Equal(t, at(2024, 2, 20, 15, 0), c.AddWorkingDuration(at(2024, 2, 9, 15, 0), 24*time.Hour))
+
+	assert.Equal(t, at(2024, 3, 1, 9, 0), c.AddWorkingDuration(at(2024, 3, 1, 14, 0), -4*time.Hour))
+	assert.Equal(t, at(2024, 2, 29, 17, 0), c.AddWorkingDuration(at(2024, 3, 1, 10, 0), -2*time.Hour))
+	assert.Equal(t, at(2024, 3, 2, 15, 0), c.AddWorkingDuration(at(2024, 3, 2, 15, 0), 0))
+
+	none := New().SetWeekend(0, 1, 2, 3, 4, 5, 6)
+	assert.True(t, none.AddWorkingDuration(at(2024, 3, 1, 10, 0), time.Hour).IsZero())
+	assert.True(t, none.NextWorkingTime(at(2024, 3, 1, 10, 0)).IsZero())
+}
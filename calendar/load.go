@@ -0,0 +1,136 @@
+package calendar
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Load 从 r 加载节假日与调休工作日，格式如下:
+//
+//	# 注释
+//	weekend = sat, sun
+//	hours = 09:00-12:00, 13:00-18:00
+//
+//	[holidays]
+//	2024-10-01~2024-10-07 国庆节
+//
+//	[workdays]
+//	2024-09-29 国庆节调休
+//	2024-10-12 国庆节调休
+//
+// 日期后的名称可省略，weekend 与 hours 为可选项，留空的 weekend 表示没有周末
+func (c *Calendar) Load(r io.Reader) error {
+	section := ""
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+
+		if line[0] == '[' {
+			if !strings.HasSuffix(line, "]") {
+				return fmt.Errorf("calendar: line %d: invalid section %q", lineNo, line)
+			}
+			section = strings.ToLower(strings.TrimSpace(line[1 : len(line)-1]))
+			if section != "holidays" && section != "workdays" {
+				return fmt.Errorf("calendar: line %d: unknown section %q", lineNo, section)
+			}
+			continue
+		}
+
+		var err error
+		switch section {
+		case "holidays", "workdays":
+			dates, name := line, ""
+			if pos := strings.IndexAny(line, " \t"); pos >= 0 {
+				dates, name = line[:pos], strings.TrimSpace(line[pos+1:])
+			}
+			if section == "holidays" {
+				err = c.AddHoliday(dates, name)
+			} else {
+				err = c.AddWorkday(dates, name)
+			}
+		default:
+			err = c.setOption(line)
+		}
+		if err != nil {
+			return fmt.Errorf("calendar: line %d: %s", lineNo, strings.TrimPrefix(err.Error(), "calendar: "))
+		}
+	}
+	return scanner.Err()
+}
+
+// LoadFile 从文件加载节假日与调休工作日，格式见 Load
+func (c *Calendar) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := c.Load(f); err != nil {
+		return fmt.Errorf("calendar: %s: %s", path, strings.TrimPrefix(err.Error(), "calendar: "))
+	}
+	return nil
+}
+
+func (c *Calendar) setOption(line string) error {
+	pos := strings.Index(line, "=")
+	if pos < 0 {
+		return fmt.Errorf("calendar: invalid line %q", line)
+	}
+	key := strings.ToLower(strings.TrimSpace(line[:pos]))
+	var values []string
+	for _, v := range strings.Split(line[pos+1:], ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	switch key {
+	case "weekend":
+		days, err := parseWeekdays(values)
+		if err != nil {
+			return err
+		}
+		c.SetWeekend(days...)
+		return nil
+	case "hours":
+		return c.SetHours(values...)
+	}
+	return fmt.Errorf("calendar: unknown option %q", key)
+}
+
+// 解析星期，支持 sun / sunday / 0 形式，7 等同于周日
+func parseWeekdays(values []string) ([]time.Weekday, error) {
+	days := make([]time.Weekday, 0, len(values))
+	for _, v := range values {
+		lower := strings.ToLower(v)
+		found := false
+		for d := time.Sunday; d <= time.Saturday; d++ {
+			name := strings.ToLower(d.String())
+			if lower == name || lower == name[:3] {
+				days = append(days, d)
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 || n > 7 {
+			return nil, fmt.Errorf("calendar: invalid weekday %q", v)
+		}
+		days = append(days, time.Weekday(n%7))
+	}
+	return days, nil
+}
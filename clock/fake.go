@@ -0,0 +1,202 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Fake 可手动推进的时钟，定时器只在 Advance 时按触发时间顺序触发，可安全地并发使用
+//
+//	fc := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+//	go worker(fc)
+//	fc.BlockUntil(1)         // 等待 worker 创建定时器
+//	fc.Advance(time.Minute)  // 触发定时器
+type Fake struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+// 等待中的定时器或周期定时器
+type fakeWaiter struct {
+	clock  *Fake
+	at     time.Time
+	period time.Duration
+	ch     chan time.Time
+}
+
+// NewFake 创建以 now 为当前时间的 Fake 时钟
+func NewFake(now time.Time) *Fake {
+	f := &Fake{now: now}
+	f.cond = sync.NewCond(&f.mu)
+	return f
+}
+
+// Now 返回当前时间
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Since 返回 t 到当前时间的时长
+func (f *Fake) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+// After 返回 d 之后触发的通道
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	return f.NewTimer(d).C()
+}
+
+// Sleep 阻塞直到时钟被推进 d
+func (f *Fake) Sleep(d time.Duration) {
+	<-f.After(d)
+}
+
+// NewTimer 创建定时器，d <= 0 时立即触发
+func (f *Fake) NewTimer(d time.Duration) Timer {
+	w := &fakeWaiter{clock: f, ch: make(chan time.Time, 1)}
+	w.Reset(d)
+	return fakeTimer{w}
+}
+
+// NewTicker 创建周期定时器，d <= 0 时 panic
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+	w := &fakeWaiter{clock: f, period: d, ch: make(chan time.Time, 1)}
+	w.Reset(d)
+	return fakeTicker{w}
+}
+
+// Advance 将时钟推进 d，并按触发时间顺序触发到期的定时器
+//
+// 通道已满(上一次触发未被读取)时丢弃本次触发，与 time.Ticker 一致
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	target := f.now.Add(d)
+	for len(f.waiters) > 0 && !f.waiters[0].at.After(target) {
+		w := f.waiters[0]
+		f.waiters = f.waiters[1:]
+		f.now = w.at
+		select {
+		case w.ch <- w.at:
+		default:
+		}
+		if w.period > 0 {
+			w.at = w.at.Add(w.period)
+			f.add(w)
+		}
+	}
+	f.now = target
+}
+
+// Set 将时钟设置为 t，t 早于当前时间时只修改时间而不触发定时器
+func (f *Fake) Set(t time.Time) {
+	if d := t.Sub(f.Now()); d > 0 {
+		f.Advance(d)
+		return
+	}
+	f.mu.Lock()
+	f.now = t
+	f.mu.Unlock()
+}
+
+// BlockUntil 阻塞直到至少有 n 个定时器(含 Sleep、After、Ticker)处于等待状态
+func (f *Fake) BlockUntil(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for len(f.waiters) < n {
+		f.cond.Wait()
+	}
+}
+
+// Waiters 返回等待中的定时器个数
+func (f *Fake) Waiters() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.waiters)
+}
+
+// 按触发时间插入等待队列，需持有 f.mu
+func (f *Fake) add(w *fakeWaiter) {
+	i := sort.Search(len(f.waiters), func(i int) bool { return f.waiters[i].at.After(w.at) })
+	f.waiters = append(f.waiters, nil)
+	copy(f.waiters[i+1:], f.waiters[i:])
+	f.waiters[i] = w
+	f.cond.Broadcast()
+}
+
+// 从等待队列移除，需持有 f.mu
+func (f *Fake) remove(w *fakeWaiter) bool {
+	for i, item := range f.waiters {
+		if item == w {
+			f.waiters = append(f.waiters[:i], f.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (w *fakeWaiter) C() <-chan time.Time {
+	return w.ch
+}
+
+func (w *fakeWaiter) Stop() bool {
+	f := w.clock
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.remove(w)
+}
+
+func (w *fakeWaiter) Reset(d time.Duration) bool {
+	f := w.clock
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return w.reset(d)
+}
+
+// 重新设置触发时间，需持有 f.mu
+func (w *fakeWaiter) reset(d time.Duration) bool {
+	f := w.clock
+	active := f.remove(w)
+	w.at = f.now.Add(d)
+	if d <= 0 && w.period == 0 {
+		select {
+		case w.ch <- f.now:
+		default:
+		}
+		return active
+	}
+	f.add(w)
+	return active
+}
+
+type fakeTimer struct {
+	*fakeWaiter
+}
+
+type fakeTicker struct {
+	*fakeWaiter
+}
+
+func (t fakeTicker) Stop() {
+	t.fakeWaiter.Stop()
+}
+
+func (t fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("clock: non-positive interval for Ticker.Reset")
+	}
+	f := t.clock
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t.period = d
+	t.reset(d)
+}
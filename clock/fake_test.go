@@ -0,0 +1,137 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func fired(ch <-chan time.Time) (time.Time, bool) {
+	select {
+	case t := <-ch:
+		return t, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func TestFakeTimer(t *testing.T) {
+	fc := NewFake(epoch)
+	assert.Equal(t, epoch, fc.Now())
+
+	timer := fc.NewTimer(time.Minute)
+	fc.Advance(59 * time.Second)
+	_, ok := fired(timer.C())
+	assert.False(t, ok)
+	assert.Equal(t, 59*time.Second, fc.Since(epoch))
+
+	fc.Advance(time.Second)
+	at, ok := fired(timer.C())
+	assert.True(t, ok)
+	assert.Equal(t, epoch.Add(time.Minute), at)
+	assert.False(t, timer.Stop())
+
+	assert.False(t, timer.Reset(time.Minute))
+	assert.True(t, timer.Stop())
+	fc.Advance(time.Hour)
+	_, ok = fired(timer.C())
+	assert.False(t, ok)
+
+	// d <= 0 立即触发
+	_, ok = fired(fc.NewTimer(0).C())
+	assert.True(t, ok)
+	assert.Equal(t, 0, fc.Waiters())
+}
+
+func TestFakeOrder(t *testing.T) {
+	fc := NewFake(epoch)
+	a := fc.After(3 * time.Second)
+	b := fc.After(time.Second)
+	c := fc.After(2 * time.Second)
+	assert.Equal(t, 3, fc.Waiters())
+
+	// 一次推进时按触发时间顺序触发，每个定时器收到各自的触发时间
+	fc.Advance(10 * time.Second)
+	for i, ch := range []<-chan time.Time{b, c, a} {
+		at, ok := fired(ch)
+		assert.True(t, ok)
+		assert.Equal(t, epoch.Add(time.Duration(i+1)*time.Second), at)
+	}
+	assert.Equal(t, epoch.Add(10*time.Second), fc.Now())
+}
+
+func TestFakeTicker(t *testing.T) {
+	fc := NewFake(epoch)
+	ticker := fc.NewTicker(time.Second)
+	for i := 1; i <= 3; i++ {
+		fc.Advance(time.Second)
+		at, ok := fired(ticker.C())
+		assert.True(t, ok)
+		assert.Equal(t, epoch.Add(time.Duration(i)*time.Second), at)
+	}
+
+	// 未读取的触发被丢弃
+	fc.Advance(5 * time.Second)
+	at, ok := fired(ticker.C())
+	assert.True(t, ok)
+	assert.Equal(t, epoch.Add(4*time.Second), at)
+	_, ok = fired(ticker.C())
+	assert.False(t, ok)
+
+	ticker.Reset(time.Minute)
+	fc.Advance(time.Second)
+	_, ok = fired(ticker.C())
+	assert.False(t, ok)
+	fc.Advance(time.Minute)
+	_, ok = fired(ticker.C())
+	assert.True(t, ok)
+
+	ticker.Stop()
+	assert.Equal(t, 0, fc.Waiters())
+	assert.Panics(t, func() { fc.NewTicker(0) })
+}
+
+func TestFakeSleep(t *testing.T) {
+	fc := NewFake(epoch)
+	done := make(chan time.Time)
+	go func() {
+		fc.Sleep(time.Hour)
+		done <- fc.Now()
+	}()
+
+	fc.BlockUntil(1)
+	fc.Advance(30 * time.Minute)
+	select {
+	case <-done:
+		t.Fatal("Sleep returned early")
+	default:
+	}
+	fc.Advance(30 * time.Minute)
+	assert.Equal(t, epoch.Add(time.Hour), <-done)
+
+	fc.Set(epoch)
+	assert.Equal(t, epoch, fc.Now())
+	timer := fc.NewTimer(time.Minute)
+	fc.Set(epoch.Add(time.Hour))
+	_, ok := fired(timer.C())
+	assert.True(t, ok)
+}
+
+func TestReal(t *testing.T) {
+	start := Real.Now()
+	Real.Sleep(time.Millisecond)
+	assert.True(t, Real.Since(start) >= time.Millisecond)
+
+	timer := Real.NewTimer(time.Millisecond)
+	<-timer.C()
+	<-Real.After(time.Millisecond)
+
+	ticker := Real.NewTicker(time.Millisecond)
+	<-ticker.C()
+	ticker.Reset(2 * time.Millisecond)
+	<-ticker.C()
+	ticker.Stop()
+}
@@ -0,0 +1,195 @@
+package config
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arnoluo/gu"
+)
+
+// 可绑定的结构体字段
+type field struct {
+	key        string
+	env        string
+	flag       string
+	def        string
+	hasDefault bool
+	value      reflect.Value
+}
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// 遍历可寻址的结构体 rv 中所有可绑定的字段
+func collectFields(rv reflect.Value, envPrefix string) []*field {
+	var fields []*field
+	walkStruct(rv, "", envPrefix, &fields)
+	return fields
+}
+
+func walkStruct(rv reflect.Value, prefix, envPrefix string, fields *[]*field) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		name := sf.Tag.Get("config")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(sf.Name)
+		}
+		key := joinKey(prefix, name)
+
+		fv := rv.Field(i)
+		if isNested(fv) {
+			walkStruct(fv, key, envPrefix, fields)
+			continue
+		}
+
+		f := &field{key: key, value: fv}
+		if f.env = sf.Tag.Get("env"); f.env == "" {
+			f.env = envPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+		}
+		if f.flag = sf.Tag.Get("flag"); f.flag == "" {
+			f.flag = key
+		}
+		f.def, f.hasDefault = sf.Tag.Lookup("default")
+		*fields = append(*fields, f)
+	}
+}
+
+// 嵌套结构体(未实现 TextUnmarshaler)需要展开
+func isNested(fv reflect.Value) bool {
+	return fv.Kind() == reflect.Struct && !reflect.PtrTo(fv.Type()).Implements(textUnmarshalerType)
+}
+
+// 将字符串值写入字段，数值转换使用 gu.At
+func setValue(fv reflect.Value, raw string) error {
+	if fv.CanAddr() {
+		if u, ok := fv.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			return u.UnmarshalText([]byte(raw))
+		}
+	}
+
+	if fv.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(int64(d))
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		if err := gu.At.Int64(raw, &n); err != nil {
+			return err
+		}
+		if fv.OverflowInt(n) {
+			return fmt.Errorf("value %s out of range %s", raw, fv.Type())
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var n uint64
+		if err := gu.At.Uint64(raw, &n); err != nil {
+			return err
+		}
+		if fv.OverflowUint(n) {
+			return fmt.Errorf("value %s out of range %s", raw, fv.Type())
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		if err := gu.At.Float(raw, &f); err != nil {
+			return err
+		}
+		fv.SetFloat(f)
+	case reflect.Slice:
+		var parts []string
+		if raw = strings.TrimSpace(raw); raw != "" {
+			parts = strings.Split(raw, ",")
+		}
+		slice := reflect.MakeSlice(fv.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := setValue(slice.Index(i), strings.TrimSpace(part)); err != nil {
+				return err
+			}
+		}
+		fv.Set(slice)
+	case reflect.Ptr:
+		elem := reflect.New(fv.Type().Elem())
+		if err := setValue(elem.Elem(), raw); err != nil {
+			return err
+		}
+		fv.Set(elem)
+	default:
+		return fmt.Errorf("unsupported field type %s", fv.Type())
+	}
+
+	return nil
+}
+
+// 解析命令行参数，支持 -name value, --name value, --name=value，bool 字段可省略值
+//
+// 未定义的参数与位置参数将被忽略，"--" 之后的参数不再解析
+func parseArgs(args []string, fields []*field) (map[string]string, error) {
+	byFlag := make(map[string]*field, len(fields))
+	for _, f := range fields {
+		byFlag[f.flag] = f
+	}
+
+	flags := map[string]string{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			break
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			continue
+		}
+
+		name := strings.TrimLeft(arg, "-")
+		value, hasValue := "", false
+		if pos := strings.Index(name, "="); pos >= 0 {
+			name, value, hasValue = name[:pos], name[pos+1:], true
+		}
+
+		f, ok := byFlag[name]
+		if !ok {
+			continue
+		}
+
+		if !hasValue {
+			if f.value.Kind() == reflect.Bool {
+				value = "true"
+			} else if i+1 < len(args) {
+				i++
+				value = args[i]
+			} else {
+				return nil, fmt.Errorf("config: flag --%s needs a value", name)
+			}
+		}
+		flags[name] = value
+	}
+
+	return flags, nil
+}
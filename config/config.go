@@ -0,0 +1,225 @@
+// Package config 将默认值、配置文件、环境变量和命令行参数按优先级合并到结构体中
+//
+// 优先级(由低到高): default 标签 < 配置文件(按顺序，后者覆盖前者) < 环境变量 < 命令行参数
+//
+// 字段标签:
+//
+//	config:"name"   配置键名，默认为小写字段名，"-" 表示忽略该字段；嵌套结构体以 "." 连接
+//	env:"NAME"      环境变量名，默认为 前缀 + 大写键名(. 替换为 _)
+//	flag:"name"     命令行参数名，默认为键名，如 --db.host=localhost
+//	default:"val"   默认值
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/arnoluo/gu"
+)
+
+// Source 配置值来源
+type Source int
+
+const (
+	SourceNone Source = iota
+	SourceDefault
+	SourceFile
+	SourceEnv
+	SourceFlag
+)
+
+func (s Source) String() string {
+	switch s {
+	case SourceDefault:
+		return "default"
+	case SourceFile:
+		return "file"
+	case SourceEnv:
+		return "env"
+	case SourceFlag:
+		return "flag"
+	}
+	return "none"
+}
+
+// Value 合并后的配置值及其来源
+type Value struct {
+	Raw    string
+	Source Source
+	// 文件路径、环境变量名或命令行参数名，默认值时为空
+	Origin string
+}
+
+// Result 合并结果，Values 以配置键名为 key
+type Result struct {
+	Values map[string]Value
+}
+
+// Get 返回指定键的配置值
+func (r *Result) Get(key string) (Value, bool) {
+	v, ok := r.Values[key]
+	return v, ok
+}
+
+// Keys 返回已排序的全部配置键
+func (r *Result) Keys() []string {
+	keys := make([]string, 0, len(r.Values))
+	for k := range r.Values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Map 返回 键名 => 原始值 的映射
+func (r *Result) Map() map[string]string {
+	m := make(map[string]string, len(r.Values))
+	for k, v := range r.Values {
+		m[k] = v.Raw
+	}
+	return m
+}
+
+// Loader 配置加载器
+type Loader struct {
+	// 配置文件，按扩展名识别格式: .json, .ini/.toml/.conf, .env
+	Files []string
+
+	// 忽略不存在的配置文件
+	IgnoreMissing bool
+
+	// 环境变量名前缀，如 "APP_"
+	EnvPrefix string
+
+	// 环境变量来源，为 nil 时使用 gu.GetEnvSource()
+	Env gu.EnvSource
+
+	// 命令行参数(不含程序名)，为 nil 时不读取命令行参数
+	Args []string
+}
+
+// Load 使用 Files 与进程命令行参数加载配置到 dst
+func Load(dst any, files ...string) (*Result, error) {
+	l := &Loader{Files: files, Args: os.Args[1:]}
+	return l.Load(dst)
+}
+
+// Load 加载配置到 dst，dst 必须为结构体指针；返回错误时 dst 保持不变
+func (l *Loader) Load(dst any) (*Result, error) {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, errors.New("config: dst must be a non-nil struct pointer")
+	}
+
+	// 绑定到副本，全部成功后再赋值给 dst
+	tmp := reflect.New(rv.Elem().Type())
+	tmp.Elem().Set(rv.Elem())
+	fields := collectFields(tmp.Elem(), l.EnvPrefix)
+
+	layers := make([]*fileLayer, 0, len(l.Files))
+	for _, path := range l.Files {
+		layer, err := readFile(path)
+		if err != nil {
+			if l.IgnoreMissing && errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		layers = append(layers, layer)
+	}
+
+	env := l.Env
+	if env == nil {
+		env = gu.GetEnvSource()
+	}
+
+	var flags map[string]string
+	if l.Args != nil {
+		var err error
+		if flags, err = parseArgs(l.Args, fields); err != nil {
+			return nil, err
+		}
+	}
+
+	res := &Result{Values: map[string]Value{}}
+	for _, f := range fields {
+		val, ok := resolve(f, layers, env, flags)
+		if !ok {
+			continue
+		}
+		if err := setValue(f.value, val.Raw); err != nil {
+			return nil, fmt.Errorf("config: %s (from %s %s): %s", f.key, val.Source, val.Origin, err.Error())
+		}
+		res.Values[f.key] = val
+	}
+
+	rv.Elem().Set(tmp.Elem())
+	return res, nil
+}
+
+// 按优先级由高到低查找字段值
+func resolve(f *field, layers []*fileLayer, env gu.EnvSource, flags map[string]string) (Value, bool) {
+	if raw, ok := flags[f.flag]; ok {
+		return Value{Raw: raw, Source: SourceFlag, Origin: "--" + f.flag}, true
+	}
+	if raw, ok := env.Lookup(f.env); ok {
+		return Value{Raw: raw, Source: SourceEnv, Origin: f.env}, true
+	}
+	for i := len(layers) - 1; i >= 0; i-- {
+		if raw, ok := layers[i].lookup(f); ok {
+			return Value{Raw: raw, Source: SourceFile, Origin: layers[i].path}, true
+		}
+	}
+	if f.hasDefault {
+		return Value{Raw: f.def, Source: SourceDefault}, true
+	}
+	return Value{}, false
+}
+
+// 单个配置文件解析结果
+type fileLayer struct {
+	path string
+	// 键名(小写) => 值
+	keys map[string]string
+	// dotenv 文件按环境变量名查找
+	env gu.MapEnv
+}
+
+func (fl *fileLayer) lookup(f *field) (string, bool) {
+	if fl.env != nil {
+		v, ok := fl.env[f.env]
+		return v, ok
+	}
+	v, ok := fl.keys[strings.ToLower(f.key)]
+	return v, ok
+}
+
+func readFile(path string) (*fileLayer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	layer := &fileLayer{path: path}
+	base := strings.ToLower(filepath.Base(path))
+	switch ext := strings.ToLower(filepath.Ext(path)); {
+	case ext == ".json":
+		layer.keys, err = parseJSON(data)
+	case ext == ".ini" || ext == ".toml" || ext == ".conf" || ext == ".cfg":
+		layer.keys, err = parseINI(data)
+	case ext == ".env" || strings.HasPrefix(base, ".env"):
+		layer.env, err = gu.ParseEnv(strings.NewReader(string(data)))
+	default:
+		err = fmt.Errorf("unsupported file format %q", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config: %s: %s", path, err.Error())
+	}
+
+	return layer, nil
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/arnoluo/gu"
+	"github.com/stretchr/testify/assert"
+)
+
+type dbConfig struct {
+	Host string `default:"localhost"`
+	Port int    `default:"3306"`
+}
+
+type appConfig struct {
+	Name    string        `default:"app"`
+	Debug   bool          `flag:"debug"`
+	Timeout time.Duration `default:"5s"`
+	Ratio   float64
+	Tags    []string
+	MaxConn uint16 `config:"max_conn"`
+	Secret  string `env:"SECRET_TOKEN"`
+	Skip    string `config:"-"`
+	DB      dbConfig
+	hidden  string
+}
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	jsonFile := writeFile(t, dir, "app.json", `{"name": "json-app", "ratio": 0.5, "tags": ["a", "b"], "db": {"host": "json-host", "port": 3307}}`)
+	iniFile := writeFile(t, dir, "app.ini", "max_conn = 10\n[db]\nport = 3308 ; comment\n")
+	envFile := writeFile(t, dir, ".env", "APP_DEBUG=true\n")
+
+	l := &Loader{
+		Files:     []string{jsonFile, iniFile, envFile, filepath.Join(dir, "missing.json")},
+		EnvPrefix: "APP_",
+		Env:       gu.MapEnv{"APP_DB_HOST": "env-host", "SECRET_TOKEN": "s3cr3t"},
+		Args:      []string{"serve", "--db.port", "3309", "--debug=false", "-unknown"},
+	}
+
+	var cfg appConfig
+	_, err := l.Load(&cfg)
+	assert.NotNil(t, err)
+
+	l.IgnoreMissing = true
+	res, err := l.Load(&cfg)
+	assert.Nil(t, err)
+	assert.Equal(t, appConfig{
+		Name:    "json-app",
+		Debug:   false,
+		Timeout: 5 * time.Second,
+		Ratio:   0.5,
+		Tags:    []string{"a", "b"},
+		MaxConn: 10,
+		Secret:  "s3cr3t",
+		DB:      dbConfig{Host: "env-host", Port: 3309},
+	}, cfg)
+
+	v, ok := res.Get("timeout")
+	assert.True(t, ok)
+	assert.Equal(t, Value{Raw: "5s", Source: SourceDefault}, v)
+
+	v, _ = res.Get("name")
+	assert.Equal(t, Value{Raw: "json-app", Source: SourceFile, Origin: jsonFile}, v)
+
+	v, _ = res.Get("max_conn")
+	assert.Equal(t, Value{Raw: "10", Source: SourceFile, Origin: iniFile}, v)
+
+	v, _ = res.Get("db.host")
+	assert.Equal(t, Value{Raw: "env-host", Source: SourceEnv, Origin: "APP_DB_HOST"}, v)
+
+	v, _ = res.Get("db.port")
+	assert.Equal(t, Value{Raw: "3309", Source: SourceFlag, Origin: "--db.port"}, v)
+
+	v, _ = res.Get("debug")
+	assert.Equal(t, SourceFlag, v.Source)
+
+	_, ok = res.Get("skip")
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"db.host", "db.port", "debug", "max_conn", "name", "ratio", "secret", "tags", "timeout"}, res.Keys())
+	assert.Equal(t, "env-host", res.Map()["db.host"])
+}
+
+func TestLoadEnvFile(t *testing.T) {
+	// dotenv 文件优先级低于环境变量
+	dir := t.TempDir()
+	envFile := writeFile(t, dir, "app.env", "DB_HOST=file-host\nDB_PORT=1\n")
+
+	l := &Loader{Files: []string{envFile}, Env: gu.MapEnv{"DB_PORT": "2"}}
+
+	var w struct{ DB dbConfig }
+	res, err := l.Load(&w)
+	assert.Nil(t, err)
+	assert.Equal(t, dbConfig{Host: "file-host", Port: 2}, w.DB)
+
+	v, _ := res.Get("db.host")
+	assert.Equal(t, Value{Raw: "file-host", Source: SourceFile, Origin: envFile}, v)
+}
+
+func TestLoadErrors(t *testing.T) {
+	var cfg appConfig
+	_, err := (&Loader{}).Load(cfg)
+	assert.NotNil(t, err)
+
+	_, err = (&Loader{Env: gu.MapEnv{"DB_PORT": "abc"}}).Load(&cfg)
+	assert.NotNil(t, err)
+
+	_, err = (&Loader{Env: gu.MapEnv{"MAX_CONN": "70000"}}).Load(&cfg)
+	assert.NotNil(t, err)
+
+	_, err = (&Loader{Env: gu.MapEnv{}, Args: []string{"--name"}}).Load(&cfg)
+	assert.NotNil(t, err)
+
+	dir := t.TempDir()
+	_, err = (&Loader{Files: []string{writeFile(t, dir, "app.yaml", "a: 1")}}).Load(&cfg)
+	assert.NotNil(t, err)
+
+	_, err = (&Loader{Files: []string{writeFile(t, dir, "bad.json", "{")}}).Load(&cfg)
+	assert.NotNil(t, err)
+
+	// 后续字段失败时不修改 dst
+	cfg = appConfig{Name: "keep", Skip: "skip"}
+	_, err = (&Loader{Env: gu.MapEnv{"NAME": "changed", "RATIO": "0.5", "DB_PORT": "abc"}}).Load(&cfg)
+	assert.NotNil(t, err)
+	assert.Equal(t, appConfig{Name: "keep", Skip: "skip"}, cfg)
+}
+
+func TestSourceString(t *testing.T) {
+	assert.Equal(t, "none", SourceNone.String())
+	assert.Equal(t, "default", SourceDefault.String())
+	assert.Equal(t, "file", SourceFile.String())
+	assert.Equal(t, "env", SourceEnv.String())
+	assert.Equal(t, "flag", SourceFlag.String())
+}
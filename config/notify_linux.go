@@ -0,0 +1,85 @@
+//go:build linux
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+// 基于 inotify 的文件变化通知，监听配置文件所在目录以兼容编辑器的替换写入
+type notifier struct {
+	f      *os.File
+	events chan struct{}
+}
+
+func newNotifier(paths []string) (*notifier, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string]bool{}
+	dirs := map[int32]string{}
+	const mask = syscall.IN_MODIFY | syscall.IN_CLOSE_WRITE | syscall.IN_CREATE |
+		syscall.IN_DELETE | syscall.IN_MOVED_TO | syscall.IN_MOVED_FROM
+	for _, path := range paths {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			syscall.Close(fd)
+			return nil, err
+		}
+		files[abs] = true
+
+		dir := filepath.Dir(abs)
+		wd, err := syscall.InotifyAddWatch(fd, dir, mask)
+		if err != nil {
+			syscall.Close(fd)
+			return nil, err
+		}
+		dirs[int32(wd)] = dir
+	}
+
+	// 非阻塞 fd 交由 runtime poller 管理，Close 时 Read 会立即返回
+	n := &notifier{f: os.NewFile(uintptr(fd), "inotify"), events: make(chan struct{}, 1)}
+	go n.read(dirs, files)
+	return n, nil
+}
+
+func (n *notifier) read(dirs map[int32]string, files map[string]bool) {
+	defer close(n.events)
+
+	buf := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+	for {
+		size, err := n.f.Read(buf)
+		if err != nil {
+			return
+		}
+
+		matched := false
+		for offset := 0; offset+syscall.SizeofInotifyEvent <= size; {
+			ev := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			nameStart := offset + syscall.SizeofInotifyEvent
+			name := strings.TrimRight(string(buf[nameStart:nameStart+int(ev.Len)]), "\x00")
+			offset = nameStart + int(ev.Len)
+
+			if dir, ok := dirs[ev.Wd]; ok && files[filepath.Join(dir, name)] {
+				matched = true
+			}
+		}
+
+		if matched {
+			select {
+			case n.events <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+func (n *notifier) close() {
+	n.f.Close()
+}
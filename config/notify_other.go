@@ -0,0 +1,16 @@
+//go:build !linux
+
+package config
+
+import "errors"
+
+// 非 Linux 平台不支持 inotify，Watcher 使用轮询
+type notifier struct {
+	events chan struct{}
+}
+
+func newNotifier(paths []string) (*notifier, error) {
+	return nil, errors.New("config: file notification is not supported on this platform")
+}
+
+func (n *notifier) close() {}
@@ -0,0 +1,201 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 解析 json 内容，嵌套对象展开为以 "." 连接的小写键名，标量数组以 "," 连接
+func parseJSON(data []byte) (map[string]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var root map[string]any
+	if err := dec.Decode(&root); err != nil {
+		return nil, err
+	}
+
+	keys := map[string]string{}
+	flattenJSON("", root, keys)
+	return keys, nil
+}
+
+func flattenJSON(prefix string, v any, keys map[string]string) {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, item := range v {
+			flattenJSON(joinKey(prefix, strings.ToLower(k)), item, keys)
+		}
+	case []any:
+		items := make([]string, 0, len(v))
+		for i, item := range v {
+			switch item.(type) {
+			case map[string]any, []any:
+				flattenJSON(joinKey(prefix, strconv.Itoa(i)), item, keys)
+			default:
+				items = append(items, jsonScalar(item))
+			}
+		}
+		if len(items) == len(v) {
+			keys[prefix] = strings.Join(items, ",")
+		}
+	case nil:
+	default:
+		keys[prefix] = jsonScalar(v)
+	}
+}
+
+func jsonScalar(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return fmt.Sprint(v)
+}
+
+// 解析 ini / 简易 toml 内容
+//
+// 支持 [section] 与 [a.b] 分节、key = value、# 与 ; 注释、引号字符串以及单行数组 [1, "a"]
+func parseINI(data []byte) (map[string]string, error) {
+	keys := map[string]string{}
+	section := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+
+		if line[0] == '[' {
+			end := strings.Index(line, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: invalid section %q", lineNo, line)
+			}
+			section = strings.ToLower(strings.TrimSpace(line[1:end]))
+			continue
+		}
+
+		pos := strings.Index(line, "=")
+		if pos <= 0 {
+			return nil, fmt.Errorf("line %d: invalid line %q", lineNo, line)
+		}
+
+		key := strings.ToLower(strings.Trim(strings.TrimSpace(line[:pos]), `"`))
+		value, err := parseINIValue(strings.TrimSpace(line[pos+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", lineNo, err.Error())
+		}
+		keys[joinKey(section, key)] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func parseINIValue(raw string) (string, error) {
+	if raw == "" {
+		return "", nil
+	}
+
+	switch raw[0] {
+	case '"', '\'':
+		value, _, err := unquote(raw)
+		return value, err
+	case '[':
+		return parseINIArray(raw)
+	}
+
+	return stripComment(raw), nil
+}
+
+func parseINIArray(raw string) (string, error) {
+	var items []string
+	rest := strings.TrimSpace(raw[1:])
+	for {
+		if rest == "" {
+			return "", fmt.Errorf("unterminated array")
+		}
+		if rest[0] == ']' {
+			return strings.Join(items, ","), nil
+		}
+
+		var item string
+		if rest[0] == '"' || rest[0] == '\'' {
+			value, n, err := unquote(rest)
+			if err != nil {
+				return "", err
+			}
+			item, rest = value, rest[n:]
+		} else {
+			end := strings.IndexAny(rest, ",]")
+			if end < 0 {
+				return "", fmt.Errorf("unterminated array")
+			}
+			item, rest = strings.TrimSpace(rest[:end]), rest[end:]
+		}
+		items = append(items, item)
+
+		rest = strings.TrimSpace(rest)
+		if strings.HasPrefix(rest, ",") {
+			rest = strings.TrimSpace(rest[1:])
+		}
+	}
+}
+
+// 解析引号包裹的字符串，返回值与消耗的字节数；双引号内支持转义
+func unquote(raw string) (string, int, error) {
+	quote := raw[0]
+	var sb strings.Builder
+	for i := 1; i < len(raw); i++ {
+		c := raw[i]
+		if c == quote {
+			return sb.String(), i + 1, nil
+		}
+		if c == '\\' && quote == '"' && i+1 < len(raw) {
+			i++
+			switch raw[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case 'r':
+				sb.WriteByte('\r')
+			default:
+				sb.WriteByte(raw[i])
+			}
+			continue
+		}
+		sb.WriteByte(c)
+	}
+	return "", 0, fmt.Errorf("unterminated quote")
+}
+
+func stripComment(raw string) string {
+	for _, mark := range []string{" #", " ;", "\t#", "\t;"} {
+		if pos := strings.Index(raw, mark); pos >= 0 {
+			raw = raw[:pos]
+		}
+	}
+	return strings.TrimSpace(raw)
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseJSON(t *testing.T) {
+	keys, err := parseJSON([]byte(`{"A": {"B": 1, "C": [1, 2]}, "D": [{"E": true}], "F": null, "G": "x"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"a.b": "1", "a.c": "1,2", "d.0.e": "true", "g": "x"}, keys)
+
+	_, err = parseJSON([]byte(`[1]`))
+	assert.NotNil(t, err)
+}
+
+func TestParseINI(t *testing.T) {
+	content := `
+# comment
+name = "my app" # ignored
+path = 'C:\dir'
+escaped = "a\tb"
+ports = [80, "443" , 8080]
+
+[server.http]
+Host = 0.0.0.0 ; comment
+`
+	keys, err := parseINI([]byte(content))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"name":             "my app",
+		"path":             `C:\dir`,
+		"escaped":          "a\tb",
+		"ports":            "80,443,8080",
+		"server.http.host": "0.0.0.0",
+	}, keys)
+
+	for _, bad := range []string{"[section", "novalue", `a = "open`, "a = [1, 2", `a = ["x`} {
+		_, err = parseINI([]byte(bad))
+		assert.NotNil(t, err, bad)
+	}
+}
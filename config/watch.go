@@ -0,0 +1,310 @@
+package config
+
+import (
+	"errors"
+	"hash/fnv"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrWatcherClosed 监听器已关闭
+var ErrWatcherClosed = errors.New("config: watcher is closed")
+
+// Change 配置变更通知
+type Change[T any] struct {
+	Old *T
+	New *T
+	// 发生变化(新增、删除或修改)的配置键，已排序
+	Keys   []string
+	Result *Result
+}
+
+// WatchOptions 监听选项
+type WatchOptions[T any] struct {
+	// 轮询间隔，默认 1s
+	Interval time.Duration
+
+	// 强制使用轮询，否则在 Linux 下优先使用 inotify
+	Poll bool
+
+	// 校验新配置，返回错误时保留当前配置；T 实现 Validate() error 时也会被调用
+	Validate func(*T) error
+
+	// 重新加载或校验失败时的回调
+	OnError func(error)
+}
+
+type snapshot[T any] struct {
+	value  *T
+	result *Result
+}
+
+// Watcher 监听配置文件变化，重新加载并原子替换当前配置
+type Watcher[T any] struct {
+	loader *Loader
+	opts   WatchOptions[T]
+
+	current atomic.Pointer[snapshot[T]]
+
+	mu     sync.Mutex
+	subs   map[int]func(Change[T])
+	nextID int
+	closed bool
+	stop   chan struct{}
+	done   chan struct{}
+
+	reloadMu sync.Mutex
+	stamps   map[string]uint64
+}
+
+// NewWatcher 创建监听器并完成首次加载，首次加载或校验失败时返回错误
+func NewWatcher[T any](l *Loader, opts WatchOptions[T]) (*Watcher[T], error) {
+	if opts.Interval <= 0 {
+		opts.Interval = time.Second
+	}
+
+	w := &Watcher[T]{loader: l, opts: opts, subs: map[int]func(Change[T]){}}
+	w.stamps = w.fileStamps()
+
+	value, res, err := w.load()
+	if err != nil {
+		return nil, err
+	}
+	w.current.Store(&snapshot[T]{value: value, result: res})
+
+	return w, nil
+}
+
+// Current 返回当前配置，返回值应视为只读
+func (w *Watcher[T]) Current() *T {
+	return w.current.Load().value
+}
+
+// Result 返回当前配置的合并结果
+func (w *Watcher[T]) Result() *Result {
+	return w.current.Load().result
+}
+
+// Subscribe 订阅配置变更，返回取消订阅函数
+func (w *Watcher[T]) Subscribe(fn func(Change[T])) (cancel func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	id := w.nextID
+	w.nextID++
+	w.subs[id] = fn
+
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.subs, id)
+	}
+}
+
+// Start 在后台开始监听，重复调用无效，Close 之后调用返回 ErrWatcherClosed
+func (w *Watcher[T]) Start() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return ErrWatcherClosed
+	}
+	if w.stop != nil {
+		return nil
+	}
+
+	w.stop = make(chan struct{})
+	w.done = make(chan struct{})
+
+	var n *notifier
+	if !w.opts.Poll {
+		// inotify 不可用时回退为轮询
+		n, _ = newNotifier(w.loader.Files)
+	}
+	go w.run(n)
+	return nil
+}
+
+// Close 停止监听并等待后台任务退出，可重复及并发调用
+func (w *Watcher[T]) Close() {
+	w.mu.Lock()
+	if !w.closed && w.stop != nil {
+		close(w.stop)
+	}
+	w.closed = true
+	done := w.done
+	w.mu.Unlock()
+
+	if done != nil {
+		<-done
+	}
+}
+
+func (w *Watcher[T]) run(n *notifier) {
+	defer close(w.done)
+
+	var tick <-chan time.Time
+	var events <-chan struct{}
+	if n != nil {
+		defer n.close()
+		events = n.events
+	} else {
+		ticker := time.NewTicker(w.opts.Interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case _, ok := <-events:
+			if !ok {
+				// inotify 异常退出，回退为轮询
+				events = nil
+				ticker := time.NewTicker(w.opts.Interval)
+				defer ticker.Stop()
+				tick = ticker.C
+				continue
+			}
+			w.reportError(w.reload(false))
+		case <-tick:
+			w.reportError(w.reload(false))
+		}
+	}
+}
+
+// Reload 立即重新加载配置，校验通过且发生变化时替换当前配置并通知订阅者
+//
+// 订阅者在重新加载完成后被调用，可在回调中再次调用 Reload
+func (w *Watcher[T]) Reload() error {
+	return w.reload(true)
+}
+
+// force 为 false 时仅在配置文件内容变化时重新加载
+func (w *Watcher[T]) reload(force bool) error {
+	change, err := w.swap(force)
+	if err != nil || change == nil {
+		return err
+	}
+	w.notify(*change)
+	return nil
+}
+
+// 重新加载并替换当前配置，配置未变化时返回 nil
+func (w *Watcher[T]) swap(force bool) (*Change[T], error) {
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+
+	stamps := w.fileStamps()
+	if !force && sameStamps(stamps, w.stamps) {
+		return nil, nil
+	}
+	w.stamps = stamps
+
+	value, res, err := w.load()
+	if err != nil {
+		return nil, err
+	}
+
+	old := w.current.Load()
+	keys := diffKeys(old.result, res)
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	w.current.Store(&snapshot[T]{value: value, result: res})
+	return &Change[T]{Old: old.value, New: value, Keys: keys, Result: res}, nil
+}
+
+// 按订阅顺序通知订阅者，不持有任何锁
+func (w *Watcher[T]) notify(change Change[T]) {
+	w.mu.Lock()
+	ids := make([]int, 0, len(w.subs))
+	for id := range w.subs {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	subs := make([]func(Change[T]), 0, len(ids))
+	for _, id := range ids {
+		subs = append(subs, w.subs[id])
+	}
+	w.mu.Unlock()
+
+	for _, fn := range subs {
+		fn(change)
+	}
+}
+
+func (w *Watcher[T]) load() (*T, *Result, error) {
+	value := new(T)
+	res, err := w.loader.Load(value)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if w.opts.Validate != nil {
+		if err := w.opts.Validate(value); err != nil {
+			return nil, nil, err
+		}
+	}
+	if v, ok := any(value).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return value, res, nil
+}
+
+func (w *Watcher[T]) reportError(err error) {
+	if err != nil && w.opts.OnError != nil {
+		w.opts.OnError(err)
+	}
+}
+
+// 计算配置文件内容的摘要，不存在的文件不计入
+func (w *Watcher[T]) fileStamps() map[string]uint64 {
+	stamps := make(map[string]uint64, len(w.loader.Files))
+	for _, path := range w.loader.Files {
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		h := fnv.New64a()
+		h.Write(data)
+		stamps[path] = h.Sum64()
+	}
+	return stamps
+}
+
+func sameStamps(a, b map[string]uint64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if cur, ok := b[k]; !ok || cur != v {
+			return false
+		}
+	}
+	return true
+}
+
+// 返回两次合并结果中值不同的键
+func diffKeys(old, cur *Result) []string {
+	var keys []string
+	for k, v := range cur.Values {
+		if ov, ok := old.Values[k]; !ok || ov.Raw != v.Raw {
+			keys = append(keys, k)
+		}
+	}
+	for k := range old.Values {
+		if _, ok := cur.Values[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,163 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/arnoluo/gu"
+	"github.com/stretchr/testify/assert"
+)
+
+type toggles struct {
+	Feature bool
+	Limit   int `default:"10"`
+}
+
+func (t *toggles) Validate() error {
+	if t.Limit < 0 {
+		return errors.New("limit must not be negative")
+	}
+	return nil
+}
+
+func newTestWatcher(t *testing.T, poll bool) (*Watcher[toggles], string, chan Change[toggles]) {
+	path := writeFile(t, t.TempDir(), "app.ini", "feature = false\n")
+	w, err := NewWatcher(&Loader{Files: []string{path}, Env: gu.MapEnv{}}, WatchOptions[toggles]{
+		Interval: 10 * time.Millisecond,
+		Poll:     poll,
+	})
+	assert.Nil(t, err)
+
+	changes := make(chan Change[toggles], 4)
+	w.Subscribe(func(c Change[toggles]) { changes <- c })
+	return w, path, changes
+}
+
+func TestWatcherReload(t *testing.T) {
+	w, path, changes := newTestWatcher(t, true)
+	assert.Equal(t, toggles{Feature: false, Limit: 10}, *w.Current())
+
+	// 内容未变化时不通知
+	assert.Nil(t, w.Reload())
+	assert.Len(t, changes, 0)
+
+	assert.Nil(t, os.WriteFile(path, []byte("feature = true\nlimit = 20\n"), 0o644))
+	assert.Nil(t, w.Reload())
+
+	c := <-changes
+	assert.Equal(t, []string{"feature", "limit"}, c.Keys)
+	assert.Equal(t, toggles{Feature: false, Limit: 10}, *c.Old)
+	assert.Equal(t, toggles{Feature: true, Limit: 20}, *c.New)
+	assert.Equal(t, c.New, w.Current())
+	assert.Equal(t, "20", w.Result().Map()["limit"])
+
+	// 校验失败时保留当前配置
+	assert.Nil(t, os.WriteFile(path, []byte("limit = -1\n"), 0o644))
+	assert.NotNil(t, w.Reload())
+	assert.Equal(t, toggles{Feature: true, Limit: 20}, *w.Current())
+}
+
+func TestWatcherValidate(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "app.ini", "limit = 5\n")
+	_, err := NewWatcher(&Loader{Files: []string{path}, Env: gu.MapEnv{}}, WatchOptions[toggles]{
+		Validate: func(t *toggles) error {
+			if t.Limit < 10 {
+				return errors.New("limit too small")
+			}
+			return nil
+		},
+	})
+	assert.EqualError(t, err, "limit too small")
+}
+
+func TestWatcherUnsubscribe(t *testing.T) {
+	w, path, changes := newTestWatcher(t, true)
+	count := 0
+	cancel := w.Subscribe(func(c Change[toggles]) { count++ })
+	cancel()
+
+	assert.Nil(t, os.WriteFile(path, []byte("feature = true\n"), 0o644))
+	assert.Nil(t, w.Reload())
+	assert.Len(t, changes, 1)
+	assert.Equal(t, 0, count)
+}
+
+func testWatcherStart(t *testing.T, poll bool) {
+	w, path, changes := newTestWatcher(t, poll)
+	errs := make(chan error, 4)
+	w.opts.OnError = func(err error) { errs <- err }
+	assert.Nil(t, w.Start())
+	assert.Nil(t, w.Start())
+	defer w.Close()
+
+	// 通过重命名替换文件，模拟编辑器保存
+	tmp := filepath.Join(filepath.Dir(path), "app.ini.tmp")
+	assert.Nil(t, os.WriteFile(tmp, []byte("feature = true\n"), 0o644))
+	assert.Nil(t, os.Rename(tmp, path))
+
+	select {
+	case c := <-changes:
+		assert.Equal(t, []string{"feature"}, c.Keys)
+		assert.True(t, w.Current().Feature)
+	case err := <-errs:
+		t.Fatal(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change notification")
+	}
+}
+
+func TestWatcherClose(t *testing.T) {
+	w, _, _ := newTestWatcher(t, true)
+	assert.Nil(t, w.Start())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.Close()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, ErrWatcherClosed, w.Start())
+
+	// 未启动时关闭
+	w2, _, _ := newTestWatcher(t, true)
+	w2.Close()
+	w2.Close()
+	assert.Equal(t, ErrWatcherClosed, w2.Start())
+}
+
+func TestWatcherReloadInSubscriber(t *testing.T) {
+	w, path, changes := newTestWatcher(t, true)
+	reloads := 0
+	w.Subscribe(func(c Change[toggles]) {
+		reloads++
+		assert.Nil(t, w.Reload())
+	})
+
+	assert.Nil(t, os.WriteFile(path, []byte("feature = true\n"), 0o644))
+	assert.Nil(t, w.Reload())
+	assert.Equal(t, 1, reloads)
+	assert.Len(t, changes, 1)
+}
+
+func TestWatcherPoll(t *testing.T) {
+	testWatcherStart(t, true)
+}
+
+func TestWatcherNotify(t *testing.T) {
+	testWatcherStart(t, false)
+}
+
+func TestDiffKeys(t *testing.T) {
+	old := &Result{Values: map[string]Value{"a": {Raw: "1"}, "b": {Raw: "2"}}}
+	cur := &Result{Values: map[string]Value{"a": {Raw: "1"}, "b": {Raw: "3"}, "c": {Raw: "4"}}}
+	assert.Equal(t, []string{"b", "c"}, diffKeys(old, cur))
+	assert.Equal(t, []string{"b", "c"}, diffKeys(cur, old))
+	assert.Len(t, diffKeys(old, old), 0)
+}
@@ -0,0 +1,257 @@
+// Package cron 解析 cron 表达式并计算触发时间，不包含调度进程
+//
+// 支持 5 段(分 时 日 月 周)与 6 段(秒 分 时 日 月 周)表达式，@yearly 等宏，
+// 以及日字段的 L、L-n、LW、nW 与周字段的 nL、n#k 扩展。
+// 表达式前可使用 CRON_TZ=Asia/Shanghai 或 TZ=Asia/Shanghai 指定时区。
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var macros = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+var monthNames = map[string]int{
+	"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+	"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+}
+
+var dowNames = map[string]int{
+	"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+}
+
+// 字段取值范围
+type bounds struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	secondBounds = bounds{"second", 0, 59, nil}
+	minuteBounds = bounds{"minute", 0, 59, nil}
+	hourBounds   = bounds{"hour", 0, 23, nil}
+	domBounds    = bounds{"day of month", 1, 31, nil}
+	monthBounds  = bounds{"month", 1, 12, monthNames}
+	dowBounds    = bounds{"day of week", 0, 7, dowNames}
+)
+
+// Parse 解析 cron 表达式
+func Parse(spec string) (*Schedule, error) {
+	s := &Schedule{spec: spec}
+
+	expr := strings.TrimSpace(spec)
+	if strings.HasPrefix(expr, "CRON_TZ=") || strings.HasPrefix(expr, "TZ=") {
+		pos := strings.IndexAny(expr, " \t")
+		if pos < 0 {
+			return nil, fmt.Errorf("cron: missing fields in %q", spec)
+		}
+		tz := expr[strings.Index(expr, "=")+1 : pos]
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return nil, fmt.Errorf("cron: invalid time zone %q: %s", tz, err.Error())
+		}
+		s.loc = loc
+		expr = strings.TrimSpace(expr[pos:])
+	}
+
+	if strings.HasPrefix(expr, "@") {
+		m, ok := macros[strings.ToLower(expr)]
+		if !ok {
+			return nil, fmt.Errorf("cron: unknown macro %q", expr)
+		}
+		expr = m
+	}
+
+	fields := strings.Fields(expr)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("cron: expected 5 or 6 fields, got %d in %q", len(fields), spec)
+	}
+
+	var err error
+	if s.second, err = parseField(fields[0], secondBounds); err != nil {
+		return nil, err
+	}
+	if s.minute, err = parseField(fields[1], minuteBounds); err != nil {
+		return nil, err
+	}
+	if s.hour, err = parseField(fields[2], hourBounds); err != nil {
+		return nil, err
+	}
+	if s.month, err = parseField(fields[4], monthBounds); err != nil {
+		return nil, err
+	}
+	if err = s.parseDom(fields[3]); err != nil {
+		return nil, err
+	}
+	if err = s.parseDow(fields[5]); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// MustParse 同 Parse，解析失败时 panic
+func MustParse(spec string) *Schedule {
+	s, err := Parse(spec)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// 解析普通字段，返回取值位图
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(field, ",") {
+		v, err := parseItem(item, b)
+		if err != nil {
+			return 0, err
+		}
+		bits |= v
+	}
+	return bits, nil
+}
+
+// 解析单项: *, ?, a, a-b, */n, a-b/n, a/n
+func parseItem(item string, b bounds) (uint64, error) {
+	rangePart, step := item, 1
+	if pos := strings.Index(item, "/"); pos >= 0 {
+		n, err := strconv.Atoi(item[pos+1:])
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("cron: invalid step in %s field %q", b.name, item)
+		}
+		rangePart, step = item[:pos], n
+	}
+
+	var lo, hi int
+	switch {
+	case rangePart == "*" || rangePart == "?":
+		lo, hi = b.min, b.max
+	case strings.Contains(rangePart, "-"):
+		pos := strings.Index(rangePart, "-")
+		var err error
+		if lo, err = parseValue(rangePart[:pos], b); err != nil {
+			return 0, err
+		}
+		if hi, err = parseValue(rangePart[pos+1:], b); err != nil {
+			return 0, err
+		}
+	default:
+		v, err := parseValue(rangePart, b)
+		if err != nil {
+			return 0, err
+		}
+		lo, hi = v, v
+		if step > 1 || strings.Contains(item, "/") {
+			hi = b.max
+		}
+	}
+
+	if lo > hi {
+		return 0, fmt.Errorf("cron: invalid range in %s field %q", b.name, item)
+	}
+
+	var bits uint64
+	for v := lo; v <= hi; v += step {
+		bits |= 1 << uint(v)
+	}
+	return bits, nil
+}
+
+func parseValue(s string, b bounds) (int, error) {
+	if v, ok := b.names[strings.ToUpper(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < b.min || v > b.max {
+		return 0, fmt.Errorf("cron: invalid value %q in %s field", s, b.name)
+	}
+	return v, nil
+}
+
+// 解析日字段，支持 L, L-n, LW, nW
+func (s *Schedule) parseDom(field string) error {
+	s.domStar = strings.HasPrefix(field, "*") || field == "?"
+	for _, item := range strings.Split(field, ",") {
+		upper := strings.ToUpper(item)
+		switch {
+		case upper == "L":
+			s.lastDays |= 1
+		case upper == "LW":
+			s.lastWeekday = true
+		case strings.HasPrefix(upper, "L-"):
+			n, err := strconv.Atoi(upper[2:])
+			if err != nil || n < 0 || n > 30 {
+				return fmt.Errorf("cron: invalid day of month %q", item)
+			}
+			s.lastDays |= 1 << uint(n)
+		case strings.HasSuffix(upper, "W"):
+			n, err := parseValue(upper[:len(upper)-1], domBounds)
+			if err != nil {
+				return err
+			}
+			s.nearestWeekday |= 1 << uint(n)
+		default:
+			bits, err := parseItem(item, domBounds)
+			if err != nil {
+				return err
+			}
+			s.dom |= bits
+		}
+	}
+	return nil
+}
+
+// 解析周字段，支持 nL(当月最后一个周n)与 n#k(当月第k个周n)，7 等同于 0(周日)
+func (s *Schedule) parseDow(field string) error {
+	s.dowStar = strings.HasPrefix(field, "*") || field == "?"
+	for _, item := range strings.Split(field, ",") {
+		upper := strings.ToUpper(item)
+		switch {
+		case len(upper) > 1 && strings.HasSuffix(upper, "L"):
+			n, err := parseValue(upper[:len(upper)-1], dowBounds)
+			if err != nil {
+				return err
+			}
+			s.dowLast |= 1 << uint(n%7)
+		case strings.Contains(upper, "#"):
+			pos := strings.Index(upper, "#")
+			n, err := parseValue(upper[:pos], dowBounds)
+			if err != nil {
+				return err
+			}
+			k, err := strconv.Atoi(upper[pos+1:])
+			if err != nil || k < 1 || k > 5 {
+				return fmt.Errorf("cron: invalid day of week %q", item)
+			}
+			s.dowNth[n%7] |= 1 << uint(k)
+		default:
+			bits, err := parseItem(item, dowBounds)
+			if err != nil {
+				return err
+			}
+			// 7 等同于周日
+			if bits&(1<<7) != 0 {
+				bits = bits&^(1<<7) | 1
+			}
+			s.dow |= bits
+		}
+	}
+	return nil
+}
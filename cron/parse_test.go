@@ -0,0 +1,55 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	s, err := Parse("*/15 9-17 * * MON-FRI")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), s.second)
+	assert.Equal(t, uint64(1|1<<15|1<<30|1<<45), s.minute)
+	assert.Equal(t, uint64(0x3fe00), s.hour)
+	assert.Equal(t, uint64(0x3e), s.dow)
+	assert.True(t, s.domStar)
+	assert.False(t, s.dowStar)
+	assert.Equal(t, "*/15 9-17 * * MON-FRI", s.String())
+	assert.Nil(t, s.Location())
+
+	s, err = Parse("30 0 12 1,15 jan-mar/2 *")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1<<30), s.second)
+	assert.Equal(t, uint64(1<<1|1<<15), s.dom)
+	assert.Equal(t, uint64(1<<1|1<<3), s.month)
+
+	s, err = Parse("0 5/20 * * * 7")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1<<5|1<<25|1<<45), s.minute)
+	assert.Equal(t, uint64(1), s.dow)
+
+	s, err = Parse("CRON_TZ=Asia/Shanghai @daily")
+	assert.Nil(t, err)
+	assert.Equal(t, "Asia/Shanghai", s.Location().String())
+
+	s, err = Parse("TZ=UTC 0 0 L,L-2,15W * 5L,1#2")
+	assert.Nil(t, err)
+	assert.Equal(t, time.UTC, s.Location())
+	assert.Equal(t, uint64(1|1<<2), s.lastDays)
+	assert.Equal(t, uint64(1<<15), s.nearestWeekday)
+	assert.Equal(t, uint64(1<<5), s.dowLast)
+	assert.Equal(t, uint64(1<<2), s.dowNth[1])
+
+	for _, spec := range []string{
+		"", "* * * *", "* * * * * * *", "60 * * * * *", "* 24 * * *", "* * 0 * *",
+		"* * * 13 *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "* * * * 1#6",
+		"@every", "TZ=Nowhere/City * * * * *", "TZ=UTC", "a b c d e",
+	} {
+		_, err := Parse(spec)
+		assert.NotNil(t, err, spec)
+	}
+
+	assert.Panics(t, func() { MustParse("bad") })
+}
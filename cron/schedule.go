@@ -0,0 +1,281 @@
+package cron
+
+import (
+	"math/bits"
+	"time"
+)
+
+// 查找触发时间时最多搜索的年数，超出时返回零值时间
+const searchYears = 50
+
+// Schedule 解析后的 cron 表达式，可安全地并发使用
+type Schedule struct {
+	spec string
+	loc  *time.Location
+
+	second, minute, hour, dom, month, dow uint64
+
+	// 日 / 周字段是否为 * 或 ?，两者均受限时按 "或" 匹配
+	domStar, dowStar bool
+
+	// L-n: 位 n 表示当月倒数第 n+1 天
+	lastDays uint64
+	// LW: 当月最后一个工作日
+	lastWeekday bool
+	// nW: 距离第 n 天最近的工作日
+	nearestWeekday uint64
+	// nL: 位 n 表示当月最后一个周n
+	dowLast uint64
+	// n#k: dowNth[n] 的位 k 表示当月第 k 个周n
+	dowNth [7]uint64
+}
+
+// String 返回原始表达式
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+// Location 返回表达式指定的时区，未指定时返回 nil(使用传入时间的时区)
+func (s *Schedule) Location() *time.Location {
+	return s.loc
+}
+
+func (s *Schedule) location(t time.Time) *time.Location {
+	if s.loc != nil {
+		return s.loc
+	}
+	return t.Location()
+}
+
+// Next 返回 t 之后的下一个触发时间，搜索 50 年内无触发时间时返回零值
+//
+// 夏令时切换时，不存在的本地时间将被跳过，重复的本地时间只在第一次出现时触发
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := s.location(t)
+	t = t.In(loc)
+	start := t.Add(time.Second - time.Duration(t.Nanosecond()))
+	y, m, d := start.Date()
+	h, mi, sec := start.Clock()
+
+	day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	end := day.AddDate(searchYears, 0, 0)
+	for ; day.Before(end); day = day.AddDate(0, 0, 1) {
+		if !s.matchMonth(day.Month()) {
+			// 跳到下个月第一天
+			day = time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC)
+			h, mi, sec = 0, 0, 0
+			continue
+		}
+		if s.matchDay(day) {
+			if next, ok := s.nextInDay(day, h, mi, sec, t, loc); ok {
+				return next
+			}
+		}
+		h, mi, sec = 0, 0, 0
+	}
+	return time.Time{}
+}
+
+// Prev 返回 t 之前的上一个触发时间，搜索 50 年内无触发时间时返回零值
+func (s *Schedule) Prev(t time.Time) time.Time {
+	loc := s.location(t)
+	t = t.In(loc)
+	start := t.Add(-time.Duration(t.Nanosecond()))
+	if t.Nanosecond() == 0 {
+		start = t.Add(-time.Second)
+	}
+	y, m, d := start.Date()
+	h, mi, sec := start.Clock()
+
+	day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	end := day.AddDate(-searchYears, 0, 0)
+	for ; day.After(end); day = day.AddDate(0, 0, -1) {
+		if !s.matchMonth(day.Month()) {
+			// 跳到上个月最后一天
+			day = time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+			h, mi, sec = 23, 59, 59
+			continue
+		}
+		if s.matchDay(day) {
+			if prev, ok := s.prevInDay(day, h, mi, sec, t, loc); ok {
+				return prev
+			}
+		}
+		h, mi, sec = 23, 59, 59
+	}
+	return time.Time{}
+}
+
+// NextN 返回 t 之后的 n 个触发时间
+func (s *Schedule) NextN(t time.Time, n int) []time.Time {
+	times := make([]time.Time, 0, n)
+	for i := 0; i < n; i++ {
+		t = s.Next(t)
+		if t.IsZero() {
+			break
+		}
+		times = append(times, t)
+	}
+	return times
+}
+
+// 在 day 当天查找不早于 h:mi:sec 且晚于 after 的触发时间
+func (s *Schedule) nextInDay(day time.Time, h, mi, sec int, after time.Time, loc *time.Location) (time.Time, bool) {
+	for hh := nextBit(s.hour, h); hh >= 0; hh = nextBit(s.hour, hh+1) {
+		m0 := 0
+		if hh == h {
+			m0 = mi
+		}
+		for mm := nextBit(s.minute, m0); mm >= 0; mm = nextBit(s.minute, mm+1) {
+			s0 := 0
+			if hh == h && mm == mi {
+				s0 = sec
+			}
+			for ss := nextBit(s.second, s0); ss >= 0; ss = nextBit(s.second, ss+1) {
+				if c, ok := wallTime(day, hh, mm, ss, loc); ok && c.After(after) {
+					return c, true
+				}
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// 在 day 当天查找不晚于 h:mi:sec 且早于 before 的触发时间
+func (s *Schedule) prevInDay(day time.Time, h, mi, sec int, before time.Time, loc *time.Location) (time.Time, bool) {
+	for hh := prevBit(s.hour, h); hh >= 0; hh = prevBit(s.hour, hh-1) {
+		m0 := 59
+		if hh == h {
+			m0 = mi
+		}
+		for mm := prevBit(s.minute, m0); mm >= 0; mm = prevBit(s.minute, mm-1) {
+			s0 := 59
+			if hh == h && mm == mi {
+				s0 = sec
+			}
+			for ss := prevBit(s.second, s0); ss >= 0; ss = prevBit(s.second, ss-1) {
+				if c, ok := wallTime(day, hh, mm, ss, loc); ok && c.Before(before) {
+					return c, true
+				}
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// 返回 loc 中的本地时间，本地时间不存在时返回 false，重复时返回第一次出现的时间
+func wallTime(day time.Time, h, m, s int, loc *time.Location) (time.Time, bool) {
+	y, mo, d := day.Date()
+	c := time.Date(y, mo, d, h, m, s, 0, loc)
+	if c.Hour() != h || c.Minute() != m || c.Day() != d {
+		return c, false
+	}
+	for _, shift := range []time.Duration{-time.Hour, -30 * time.Minute} {
+		e := c.Add(shift)
+		if e.Hour() == h && e.Minute() == m && e.Day() == d {
+			return e, true
+		}
+	}
+	return c, true
+}
+
+func (s *Schedule) matchMonth(m time.Month) bool {
+	return s.month&(1<<uint(m)) != 0
+}
+
+// day 为 UTC 零点日期
+func (s *Schedule) matchDay(day time.Time) bool {
+	domOK := s.matchDom(day)
+	dowOK := s.matchDow(day)
+	switch {
+	case s.domStar && s.dowStar:
+		return true
+	case s.domStar:
+		return dowOK
+	case s.dowStar:
+		return domOK
+	}
+	return domOK || dowOK
+}
+
+func (s *Schedule) matchDom(day time.Time) bool {
+	d := day.Day()
+	if s.dom&(1<<uint(d)) != 0 {
+		return true
+	}
+
+	last := daysIn(day)
+	if s.lastDays != 0 && last-d < 64 && s.lastDays&(1<<uint(last-d)) != 0 {
+		return true
+	}
+	if s.lastWeekday && d == nearestWeekday(day, last, true) {
+		return true
+	}
+	if s.nearestWeekday != 0 {
+		for n := 1; n <= 31; n++ {
+			if s.nearestWeekday&(1<<uint(n)) != 0 && n <= last && d == nearestWeekday(day, n, false) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *Schedule) matchDow(day time.Time) bool {
+	wd := uint(day.Weekday())
+	d := day.Day()
+	if s.dow&(1<<wd) != 0 {
+		return true
+	}
+	if s.dowLast&(1<<wd) != 0 && d+7 > daysIn(day) {
+		return true
+	}
+	return s.dowNth[wd]&(1<<uint((d-1)/7+1)) != 0
+}
+
+// 返回当月距离第 n 天最近的工作日(不跨月)，last 为 true 时向前查找
+func nearestWeekday(day time.Time, n int, last bool) int {
+	t := time.Date(day.Year(), day.Month(), n, 0, 0, 0, 0, time.UTC)
+	dim := daysIn(day)
+	switch t.Weekday() {
+	case time.Saturday:
+		if n == 1 && !last {
+			return 3
+		}
+		return n - 1
+	case time.Sunday:
+		if last || n == dim {
+			return n - 2
+		}
+		return n + 1
+	}
+	return n
+}
+
+func daysIn(day time.Time) int {
+	return time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// 返回 mask 中不小于 from 的最小位，不存在时返回 -1
+func nextBit(mask uint64, from int) int {
+	if from > 63 {
+		return -1
+	}
+	m := mask >> uint(from)
+	if m == 0 {
+		return -1
+	}
+	return from + bits.TrailingZeros64(m)
+}
+
+// 返回 mask 中不大于 from 的最大位，不存在时返回 -1
+func prevBit(mask uint64, from int) int {
+	if from < 0 {
+		return -1
+	}
+	m := mask << uint(63-from)
+	if m == 0 {
+		return -1
+	}
+	return from - bits.LeadingZeros64(m)
+}
@@ -0,0 +1,129 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func date(loc *time.Location, y int, m time.Month, d, h, mi, s int) time.Time {
+	return time.Date(y, m, d, h, mi, s, 0, loc)
+}
+
+func TestNext(t *testing.T) {
+	utc := time.UTC
+	cases := []struct {
+		spec string
+		from time.Time
+		next time.Time
+	}{
+		{"* * * * * *", date(utc, 2024, 1, 1, 0, 0, 0), date(utc, 2024, 1, 1, 0, 0, 1)},
+		{"0 * * * *", date(utc, 2024, 1, 1, 10, 30, 0), date(utc, 2024, 1, 1, 11, 0, 0)},
+		{"*/15 9-17 * * MON-FRI", date(utc, 2024, 1, 5, 17, 50, 0), date(utc, 2024, 1, 8, 9, 0, 0)},
+		{"@yearly", date(utc, 2024, 6, 1, 0, 0, 0), date(utc, 2025, 1, 1, 0, 0, 0)},
+		{"@monthly", date(utc, 2024, 1, 31, 12, 0, 0), date(utc, 2024, 2, 1, 0, 0, 0)},
+		{"0 0 29 2 *", date(utc, 2024, 3, 1, 0, 0, 0), date(utc, 2028, 2, 29, 0, 0, 0)},
+		{"0 0 31 * *", date(utc, 2024, 4, 1, 0, 0, 0), date(utc, 2024, 5, 31, 0, 0, 0)},
+		// 日与周均受限时按 "或" 匹配
+		{"0 0 13 * 5", date(utc, 2024, 1, 1, 0, 0, 0), date(utc, 2024, 1, 5, 0, 0, 0)},
+		{"0 0 L * *", date(utc, 2024, 2, 1, 0, 0, 0), date(utc, 2024, 2, 29, 0, 0, 0)},
+		{"0 0 L-1 * *", date(utc, 2024, 4, 1, 0, 0, 0), date(utc, 2024, 4, 29, 0, 0, 0)},
+		// 2024-08-31 为周六
+		{"0 0 LW * *", date(utc, 2024, 8, 1, 0, 0, 0), date(utc, 2024, 8, 30, 0, 0, 0)},
+		// 2024-06-15 为周六，2024-09-01 为周日
+		{"0 0 15W * *", date(utc, 2024, 6, 1, 0, 0, 0), date(utc, 2024, 6, 14, 0, 0, 0)},
+		{"0 0 1W * *", date(utc, 2024, 8, 15, 0, 0, 0), date(utc, 2024, 9, 2, 0, 0, 0)},
+		// 2024-06-01 为周六，不跨月取到周一
+		{"0 0 1W * *", date(utc, 2024, 5, 15, 0, 0, 0), date(utc, 2024, 6, 3, 0, 0, 0)},
+		{"0 0 * * 5L", date(utc, 2024, 1, 1, 0, 0, 0), date(utc, 2024, 1, 26, 0, 0, 0)},
+		{"0 0 * * MON#2", date(utc, 2024, 1, 1, 0, 0, 0), date(utc, 2024, 1, 8, 0, 0, 0)},
+		{"0 0 * * 1#5", date(utc, 2024, 2, 1, 0, 0, 0), date(utc, 2024, 4, 29, 0, 0, 0)},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.next, MustParse(c.spec).Next(c.from), c.spec)
+	}
+
+	// 小数秒向后取整
+	from := date(utc, 2024, 1, 1, 0, 0, 0).Add(500 * time.Millisecond)
+	assert.Equal(t, date(utc, 2024, 1, 1, 0, 0, 1), MustParse("* * * * * *").Next(from))
+
+	// 不存在的日期
+	assert.True(t, MustParse("0 0 30 2 *").Next(date(utc, 2024, 1, 1, 0, 0, 0)).IsZero())
+}
+
+func TestNextLocation(t *testing.T) {
+	sh, err := time.LoadLocation("Asia/Shanghai")
+	assert.Nil(t, err)
+
+	s := MustParse("CRON_TZ=Asia/Shanghai 0 9 * * *")
+	next := s.Next(date(time.UTC, 2024, 1, 1, 0, 0, 0))
+	assert.Equal(t, date(sh, 2024, 1, 1, 9, 0, 0), next)
+	assert.Equal(t, date(time.UTC, 2024, 1, 2, 1, 0, 0), s.Next(next).UTC())
+
+	// 未指定时区时使用传入时间的时区
+	assert.Equal(t, date(sh, 2024, 1, 2, 9, 0, 0), MustParse("0 9 * * *").Next(date(sh, 2024, 1, 1, 10, 0, 0)))
+}
+
+func TestNextDST(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err)
+
+	// 2024-03-10 02:00 -> 03:00，02:30 不存在，跳过当天
+	s := MustParse("30 2 * * *")
+	assert.Equal(t, date(ny, 2024, 3, 11, 2, 30, 0), s.Next(date(ny, 2024, 3, 10, 0, 0, 0)))
+
+	// 每小时任务在跳变当天不重复也不遗漏
+	hourly := MustParse("0 * * * *").NextN(date(ny, 2024, 3, 10, 0, 30, 0), 3)
+	assert.Equal(t, []int{1, 3, 4}, []int{hourly[0].Hour(), hourly[1].Hour(), hourly[2].Hour()})
+	assert.Equal(t, time.Hour, hourly[1].Sub(hourly[0]))
+
+	// 2024-11-03 02:00 -> 01:00，01:30 重复出现，只触发第一次
+	s = MustParse("30 1 * * *")
+	first := s.Next(date(ny, 2024, 11, 3, 0, 0, 0))
+	assert.Equal(t, date(ny, 2024, 11, 3, 1, 30, 0), first)
+	_, offset := first.Zone()
+	assert.Equal(t, -4*3600, offset)
+	assert.Equal(t, date(ny, 2024, 11, 4, 1, 30, 0), s.Next(first))
+	// 从重复时段的第二次 01:00 开始查找也不会再次触发
+	assert.Equal(t, date(ny, 2024, 11, 4, 1, 30, 0), s.Next(first.Add(time.Hour-30*time.Minute)))
+
+	assert.Equal(t, first, s.Prev(date(ny, 2024, 11, 3, 12, 0, 0)))
+	assert.Equal(t, date(ny, 2024, 3, 9, 2, 30, 0), MustParse("30 2 * * *").Prev(date(ny, 2024, 3, 11, 0, 0, 0)))
+}
+
+func TestPrev(t *testing.T) {
+	utc := time.UTC
+	cases := []struct {
+		spec string
+		from time.Time
+		prev time.Time
+	}{
+		{"* * * * * *", date(utc, 2024, 1, 1, 0, 0, 0), date(utc, 2023, 12, 31, 23, 59, 59)},
+		{"0 * * * *", date(utc, 2024, 1, 1, 10, 0, 0), date(utc, 2024, 1, 1, 9, 0, 0)},
+		{"*/15 9-17 * * MON-FRI", date(utc, 2024, 1, 8, 8, 0, 0), date(utc, 2024, 1, 5, 17, 45, 0)},
+		{"@yearly", date(utc, 2024, 6, 1, 0, 0, 0), date(utc, 2024, 1, 1, 0, 0, 0)},
+		{"0 0 29 2 *", date(utc, 2028, 2, 1, 0, 0, 0), date(utc, 2024, 2, 29, 0, 0, 0)},
+		{"0 0 L * *", date(utc, 2024, 3, 15, 0, 0, 0), date(utc, 2024, 2, 29, 0, 0, 0)},
+		{"0 0 * 6 *", date(utc, 2024, 3, 15, 0, 0, 0), date(utc, 2023, 6, 30, 0, 0, 0)},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.prev, MustParse(c.spec).Prev(c.from), c.spec)
+	}
+
+	from := date(utc, 2024, 1, 1, 0, 0, 0).Add(500 * time.Millisecond)
+	assert.Equal(t, date(utc, 2024, 1, 1, 0, 0, 0), MustParse("* * * * * *").Prev(from))
+	assert.True(t, MustParse("0 0 30 2 *").Prev(date(utc, 2024, 1, 1, 0, 0, 0)).IsZero())
+}
+
+func TestNextN(t *testing.T) {
+	utc := time.UTC
+	times := MustParse("0 0 1 */4 *").NextN(date(utc, 2024, 1, 1, 0, 0, 0), 3)
+	assert.Equal(t, []time.Time{
+		date(utc, 2024, 5, 1, 0, 0, 0),
+		date(utc, 2024, 9, 1, 0, 0, 0),
+		date(utc, 2025, 1, 1, 0, 0, 0),
+	}, times)
+
+	assert.Empty(t, MustParse("0 0 30 2 *").NextN(date(utc, 2024, 1, 1, 0, 0, 0), 3))
+}
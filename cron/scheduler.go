@@ -0,0 +1,492 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/arnoluo/gu/clock"
+	"github.com/arnoluo/gu/random"
+)
+
+// Trigger 计算下一次触发时间，返回零值表示不再触发
+type Trigger interface {
+	Next(t time.Time) time.Time
+}
+
+type interval time.Duration
+
+func (d interval) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(d))
+}
+
+// Every 返回固定间隔的触发器，首次触发在调度开始后 d
+func Every(d time.Duration) Trigger {
+	return interval(d)
+}
+
+// 任务重叠策略，即上一次执行尚未结束时再次触发的处理方式
+type OverlapPolicy int
+
+const (
+	// 允许并发执行
+	OverlapAllow OverlapPolicy = iota
+	// 跳过本次触发
+	OverlapSkip
+	// 排队，上一次执行结束后立即执行
+	OverlapQueue
+)
+
+func (p OverlapPolicy) String() string {
+	switch p {
+	case OverlapSkip:
+		return "skip"
+	case OverlapQueue:
+		return "queue"
+	}
+	return "allow"
+}
+
+// Job 调度任务，ctx 在超时或调度器强制停止时取消
+type Job func(ctx context.Context) error
+
+// JobOptions 任务选项
+type JobOptions struct {
+	// 任务名称，用于错误信息，默认为 job-<id>
+	Name string
+	// 单次执行超时时间，<=0 表示不限制
+	Timeout time.Duration
+	// 重叠策略，默认 OverlapAllow
+	Overlap OverlapPolicy
+	// 每次触发随机延迟 [0, Jitter)，应小于触发间隔
+	Jitter time.Duration
+}
+
+// Options 调度器选项
+type Options struct {
+	// 时钟，默认为 clock.Real，测试中可使用 clock.Fake
+	Clock clock.Clock
+	// 随机延迟(JobOptions.Jitter)的随机数来源，默认为 random.Default()，测试中可使用 random.NewSeeded
+	Rand *random.Rand
+	// 任务返回错误、超时或 panic 时回调
+	OnError func(name string, err error)
+}
+
+// EntryID 任务 ID
+type EntryID int
+
+// Entry 任务状态快照
+type Entry struct {
+	ID      EntryID
+	Name    string
+	Next    time.Time
+	Prev    time.Time
+	Running int
+	Skipped int
+}
+
+// ErrTimeout 任务执行超时
+var ErrTimeout = errors.New("cron: job timed out")
+
+type entry struct {
+	id      EntryID
+	trigger Trigger
+	job     Job
+	opts    JobOptions
+
+	// 不含随机延迟的计划触发时间
+	planned time.Time
+	next    time.Time
+	prev    time.Time
+
+	running int
+	queued  int
+	skipped int
+}
+
+// Scheduler 进程内任务调度器
+type Scheduler struct {
+	clock   clock.Clock
+	rand    *random.Rand
+	onError func(name string, err error)
+
+	mu      sync.Mutex
+	entries map[EntryID]*entry
+	lastID  EntryID
+	started bool
+	stopped bool
+
+	wake chan struct{}
+	stop chan struct{}
+	done chan struct{}
+	wg   sync.WaitGroup
+
+	// 强制停止时取消运行中任务
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewScheduler 创建调度器
+func NewScheduler(opts Options) *Scheduler {
+	if opts.Clock == nil {
+		opts.Clock = clock.Real
+	}
+	if opts.Rand == nil {
+		opts.Rand = random.Default()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		clock:   opts.Clock,
+		rand:    opts.Rand,
+		onError: opts.OnError,
+		entries: make(map[EntryID]*entry),
+		wake:    make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Add 按触发器添加任务
+func (s *Scheduler) Add(trigger Trigger, job Job, opts JobOptions) (EntryID, error) {
+	if trigger == nil || job == nil {
+		return 0, errors.New("cron: nil trigger or job")
+	}
+	if d, ok := trigger.(interval); ok && d <= 0 {
+		return 0, fmt.Errorf("cron: invalid interval %s", time.Duration(d))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return 0, errors.New("cron: scheduler stopped")
+	}
+
+	s.lastID++
+	e := &entry{id: s.lastID, trigger: trigger, job: job, opts: opts}
+	if e.opts.Name == "" {
+		e.opts.Name = fmt.Sprintf("job-%d", e.id)
+	}
+	s.entries[e.id] = e
+	if s.started {
+		s.plan(e, s.clock.Now())
+		s.notify()
+	}
+	return e.id, nil
+}
+
+// AddFunc 按 cron 表达式添加任务
+func (s *Scheduler) AddFunc(spec string, job Job, opts JobOptions) (EntryID, error) {
+	sched, err := Parse(spec)
+	if err != nil {
+		return 0, err
+	}
+	return s.Add(sched, job, opts)
+}
+
+// AddEvery 按固定间隔添加任务
+func (s *Scheduler) AddEvery(d time.Duration, job Job, opts JobOptions) (EntryID, error) {
+	return s.Add(Every(d), job, opts)
+}
+
+// Remove 移除任务，运行中的任务不受影响
+func (s *Scheduler) Remove(id EntryID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.entries[id]; !ok {
+		return false
+	}
+	delete(s.entries, id)
+	s.notify()
+	return true
+}
+
+// Entry 返回指定任务的状态
+func (s *Scheduler) Entry(id EntryID) (Entry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[id]
+	if !ok {
+		return Entry{}, false
+	}
+	return e.snapshot(), true
+}
+
+// Entries 返回按 ID 排序的全部任务状态
+func (s *Scheduler) Entries() []Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := make([]Entry, 0, len(s.entries))
+	for _, e := range s.entries {
+		list = append(list, e.snapshot())
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+// Start 在后台开始调度，重复调用无效
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started || s.stopped {
+		return
+	}
+	s.started = true
+	now := s.clock.Now()
+	for _, e := range s.entries {
+		s.plan(e, now)
+	}
+	go s.run()
+}
+
+// Stop 停止调度并等待运行中的任务结束
+//
+// ctx 结束时取消运行中任务的 context 并返回 ctx.Err()，排队中的执行将被丢弃
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.stopped {
+		s.stopped = true
+		close(s.stop)
+		if !s.started {
+			close(s.done)
+		}
+	}
+	s.mu.Unlock()
+	<-s.done
+
+	finished := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+		s.cancel()
+		return nil
+	case <-ctx.Done():
+		s.cancel()
+		return ctx.Err()
+	}
+}
+
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Scheduler) run() {
+	defer close(s.done)
+	for {
+		s.mu.Lock()
+		var next time.Time
+		for _, e := range s.entries {
+			if !e.next.IsZero() && (next.IsZero() || e.next.Before(next)) {
+				next = e.next
+			}
+		}
+		now := s.clock.Now()
+		s.mu.Unlock()
+
+		var timer clock.Timer
+		var timerC <-chan time.Time
+		if !next.IsZero() {
+			timer = s.clock.NewTimer(next.Sub(now))
+			timerC = timer.C()
+		}
+
+		select {
+		case <-timerC:
+			s.mu.Lock()
+			now = s.clock.Now()
+			for _, e := range s.entries {
+				if !e.next.IsZero() && !e.next.After(now) {
+					e.prev = e.next
+					s.dispatch(e)
+					s.plan(e, now)
+				}
+			}
+			s.mu.Unlock()
+		case <-s.wake:
+		case <-s.stop:
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+// 计算 now 之后的下一次触发时间，需持有 s.mu
+func (s *Scheduler) plan(e *entry, now time.Time) {
+	// 按上一次计划时间推算以避免间隔漂移，错过的触发不补执行
+	var planned time.Time
+	if !e.planned.IsZero() {
+		planned = e.trigger.Next(e.planned)
+	}
+	if planned.IsZero() || !planned.After(now) {
+		if d, ok := e.trigger.(interval); ok && !e.planned.IsZero() {
+			// 固定间隔保持原有相位
+			n := now.Sub(e.planned)/time.Duration(d) + 1
+			planned = e.planned.Add(n * time.Duration(d))
+		} else {
+			planned = e.trigger.Next(now)
+		}
+	}
+	e.planned = planned
+	e.next = e.planned
+	if !e.planned.IsZero() && e.opts.Jitter > 0 {
+		e.next = e.planned.Add(time.Duration(s.rand.Uint64n(uint64(e.opts.Jitter))))
+	}
+}
+
+// 按重叠策略执行任务，需持有 s.mu
+func (s *Scheduler) dispatch(e *entry) {
+	if e.running > 0 {
+		switch e.opts.Overlap {
+		case OverlapSkip:
+			e.skipped++
+			return
+		case OverlapQueue:
+			e.queued++
+			return
+		}
+	}
+	e.running++
+	s.wg.Add(1)
+	go s.exec(e)
+}
+
+func (s *Scheduler) exec(e *entry) {
+	defer s.wg.Done()
+	for {
+		if err := s.runJob(e); err != nil && s.onError != nil {
+			s.onError(e.opts.Name, err)
+		}
+
+		s.mu.Lock()
+		if e.queued > 0 && s.ctx.Err() == nil {
+			e.queued--
+			s.mu.Unlock()
+			continue
+		}
+		e.queued = 0
+		e.running--
+		s.mu.Unlock()
+		return
+	}
+}
+
+func (s *Scheduler) runJob(e *entry) (err error) {
+	ctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+
+	var timedOut chan struct{}
+	if e.opts.Timeout > 0 {
+		tc := newTimeoutCtx(ctx, s.clock, e.opts.Timeout)
+		defer tc.stop()
+		ctx, timedOut = tc, tc.timedOut
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("cron: job %q panicked: %v", e.opts.Name, r)
+		}
+	}()
+
+	err = e.job(ctx)
+	if timedOut != nil {
+		select {
+		case <-timedOut:
+			return fmt.Errorf("cron: job %q: %w", e.opts.Name, ErrTimeout)
+		default:
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("cron: job %q: %w", e.opts.Name, err)
+	}
+	return nil
+}
+
+func (e *entry) snapshot() Entry {
+	return Entry{
+		ID:      e.id,
+		Name:    e.opts.Name,
+		Next:    e.next,
+		Prev:    e.prev,
+		Running: e.running,
+		Skipped: e.skipped,
+	}
+}
+
+// 由调度器时钟驱动超时的 context
+type timeoutCtx struct {
+	context.Context
+	deadline time.Time
+	done     chan struct{}
+	timedOut chan struct{}
+	quit     chan struct{}
+	once     sync.Once
+
+	mu  sync.Mutex
+	err error
+}
+
+func newTimeoutCtx(parent context.Context, clk clock.Clock, d time.Duration) *timeoutCtx {
+	c := &timeoutCtx{
+		Context:  parent,
+		deadline: clk.Now().Add(d),
+		done:     make(chan struct{}),
+		timedOut: make(chan struct{}),
+		quit:     make(chan struct{}),
+	}
+	timer := clk.NewTimer(d)
+	go func() {
+		defer timer.Stop()
+		select {
+		case <-timer.C():
+			close(c.timedOut)
+			c.finish(context.DeadlineExceeded)
+		case <-parent.Done():
+			c.finish(parent.Err())
+		case <-c.quit:
+		}
+	}()
+	return c
+}
+
+func (c *timeoutCtx) Deadline() (time.Time, bool) {
+	return c.deadline, true
+}
+
+func (c *timeoutCtx) Done() <-chan struct{} {
+	return c.done
+}
+
+func (c *timeoutCtx) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+func (c *timeoutCtx) finish(err error) {
+	c.once.Do(func() {
+		c.mu.Lock()
+		c.err = err
+		c.mu.Unlock()
+		close(c.done)
+	})
+}
+
+func (c *timeoutCtx) stop() {
+	close(c.quit)
+}
@@ -0,0 +1,300 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/arnoluo/gu/clock"
+	"github.com/arnoluo/gu/random"
+	"github.com/stretchr/testify/assert"
+)
+
+var start = time.Date(2024, 1, 1, 10, 0, 30, 0, time.UTC)
+
+func recv(t *testing.T, ch <-chan string) string {
+	t.Helper()
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for job")
+	}
+	return ""
+}
+
+func TestSchedulerEvery(t *testing.T) {
+	fc := clock.NewFake(start)
+	s := NewScheduler(Options{Clock: fc})
+	runs := make(chan string, 10)
+	id, err := s.AddEvery(time.Minute, func(ctx context.Context) error {
+		runs <- "tick"
+		return nil
+	}, JobOptions{Name: "every"})
+	assert.Nil(t, err)
+	s.Start()
+
+	e, ok := s.Entry(id)
+	assert.True(t, ok)
+	assert.Equal(t, "every", e.Name)
+	assert.Equal(t, start.Add(time.Minute), e.Next)
+
+	for i := 0; i < 3; i++ {
+		fc.BlockUntil(1)
+		fc.Advance(time.Minute)
+		assert.Equal(t, "tick", recv(t, runs))
+	}
+	fc.BlockUntil(1)
+	e, _ = s.Entry(id)
+	assert.Equal(t, start.Add(3*time.Minute), e.Prev)
+	assert.Equal(t, start.Add(4*time.Minute), e.Next)
+
+	// 错过的触发不补执行
+	fc.Advance(5*time.Minute + 10*time.Second)
+	assert.Equal(t, "tick", recv(t, runs))
+	fc.BlockUntil(1)
+	e, _ = s.Entry(id)
+	assert.Equal(t, start.Add(9*time.Minute), e.Next)
+
+	assert.True(t, s.Remove(id))
+	assert.False(t, s.Remove(id))
+	assert.Empty(t, s.Entries())
+	assert.Nil(t, s.Stop(context.Background()))
+	assert.Empty(t, runs)
+
+	_, err = s.AddEvery(time.Minute, func(ctx context.Context) error { return nil }, JobOptions{})
+	assert.NotNil(t, err)
+}
+
+func TestSchedulerCron(t *testing.T) {
+	fc := clock.NewFake(start)
+	s := NewScheduler(Options{Clock: fc})
+	runs := make(chan string, 10)
+	s.Start()
+
+	_, err := s.AddFunc("0 * * * *", func(ctx context.Context) error {
+		runs <- "hourly"
+		return nil
+	}, JobOptions{})
+	assert.Nil(t, err)
+	_, err = s.AddFunc("15 * * * *", func(ctx context.Context) error {
+		runs <- "quarter"
+		return nil
+	}, JobOptions{})
+	assert.Nil(t, err)
+	_, err = s.AddFunc("bad spec", func(ctx context.Context) error { return nil }, JobOptions{})
+	assert.NotNil(t, err)
+	_, err = s.AddEvery(0, func(ctx context.Context) error { return nil }, JobOptions{})
+	assert.NotNil(t, err)
+
+	entries := s.Entries()
+	assert.Equal(t, 2, len(entries))
+	assert.Equal(t, "job-1", entries[0].Name)
+	assert.Equal(t, time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC), entries[0].Next)
+	assert.Equal(t, time.Date(2024, 1, 1, 10, 15, 0, 0, time.UTC), entries[1].Next)
+
+	fc.BlockUntil(1)
+	fc.Advance(15 * time.Minute)
+	assert.Equal(t, "quarter", recv(t, runs))
+	fc.BlockUntil(1)
+	fc.Advance(45 * time.Minute)
+	assert.Equal(t, "hourly", recv(t, runs))
+
+	assert.Nil(t, s.Stop(context.Background()))
+}
+
+func TestSchedulerOverlap(t *testing.T) {
+	for _, policy := range []OverlapPolicy{OverlapSkip, OverlapQueue, OverlapAllow} {
+		fc := clock.NewFake(start)
+		s := NewScheduler(Options{Clock: fc})
+		started := make(chan string, 10)
+		release := make(chan struct{})
+		var runs int32
+		id, _ := s.AddEvery(time.Minute, func(ctx context.Context) error {
+			atomic.AddInt32(&runs, 1)
+			started <- "run"
+			<-release
+			return nil
+		}, JobOptions{Overlap: policy})
+		s.Start()
+
+		fc.BlockUntil(1)
+		fc.Advance(time.Minute)
+		recv(t, started)
+		fc.BlockUntil(1)
+		fc.Advance(time.Minute)
+		fc.BlockUntil(1)
+
+		e, _ := s.Entry(id)
+		switch policy {
+		case OverlapSkip:
+			assert.Equal(t, 1, e.Running)
+			assert.Equal(t, 1, e.Skipped)
+		case OverlapQueue:
+			assert.Equal(t, 1, e.Running)
+			assert.Equal(t, 0, e.Skipped)
+		case OverlapAllow:
+			recv(t, started)
+			assert.Equal(t, 2, e.Running)
+		}
+
+		close(release)
+		assert.Nil(t, s.Stop(context.Background()), policy.String())
+		if policy == OverlapSkip {
+			assert.Equal(t, int32(1), atomic.LoadInt32(&runs))
+		} else {
+			assert.Equal(t, int32(2), atomic.LoadInt32(&runs), policy.String())
+		}
+	}
+}
+
+func TestSchedulerErrors(t *testing.T) {
+	fc := clock.NewFake(start)
+	errs := make(chan string, 10)
+	var mu sync.Mutex
+	var last error
+	s := NewScheduler(Options{Clock: fc, OnError: func(name string, err error) {
+		mu.Lock()
+		last = err
+		mu.Unlock()
+		errs <- name
+	}})
+
+	var calls int32
+	s.AddEvery(time.Minute, func(ctx context.Context) error {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			panic("boom")
+		}
+		return errors.New("failed")
+	}, JobOptions{Name: "flaky"})
+	s.AddEvery(time.Hour, func(ctx context.Context) error {
+		deadline, ok := ctx.Deadline()
+		if !ok || !deadline.Equal(start.Add(time.Hour+10*time.Second)) {
+			return errors.New("unexpected deadline")
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	}, JobOptions{Name: "slow", Timeout: 10 * time.Second})
+	s.Start()
+
+	// panic 被恢复，调度继续
+	fc.BlockUntil(1)
+	fc.Advance(time.Minute)
+	assert.Equal(t, "flaky", recv(t, errs))
+	mu.Lock()
+	assert.True(t, strings.Contains(last.Error(), `job "flaky" panicked: boom`))
+	mu.Unlock()
+
+	fc.BlockUntil(1)
+	fc.Advance(time.Minute)
+	assert.Equal(t, "flaky", recv(t, errs))
+	mu.Lock()
+	assert.Equal(t, `cron: job "flaky": failed`, last.Error())
+	mu.Unlock()
+
+	// 超时由调度器时钟驱动
+	fc.BlockUntil(1)
+	fc.Advance(58 * time.Minute)
+	assert.Equal(t, "flaky", recv(t, errs))
+	fc.BlockUntil(2)
+	fc.Advance(10 * time.Second)
+	assert.Equal(t, "slow", recv(t, errs))
+	mu.Lock()
+	assert.True(t, errors.Is(last, ErrTimeout))
+	mu.Unlock()
+
+	assert.Nil(t, s.Stop(context.Background()))
+}
+
+func TestSchedulerStop(t *testing.T) {
+	fc := clock.NewFake(start)
+	s := NewScheduler(Options{Clock: fc})
+	started := make(chan string, 1)
+	release := make(chan struct{})
+	canceled := make(chan string, 1)
+	s.AddEvery(time.Minute, func(ctx context.Context) error {
+		started <- "run"
+		select {
+		case <-release:
+		case <-ctx.Done():
+			canceled <- "canceled"
+		}
+		return nil
+	}, JobOptions{})
+	s.Start()
+	fc.BlockUntil(1)
+	fc.Advance(time.Minute)
+	recv(t, started)
+
+	// 等待运行中的任务结束
+	stopped := make(chan error, 1)
+	go func() { stopped <- s.Stop(context.Background()) }()
+	select {
+	case <-stopped:
+		t.Fatal("Stop returned before job finished")
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(release)
+	assert.Nil(t, <-stopped)
+
+	// 超时后取消运行中的任务
+	fc = clock.NewFake(start)
+	s = NewScheduler(Options{Clock: fc})
+	s.AddEvery(time.Minute, func(ctx context.Context) error {
+		started <- "run"
+		<-ctx.Done()
+		canceled <- "canceled"
+		return ctx.Err()
+	}, JobOptions{})
+	s.Start()
+	fc.BlockUntil(1)
+	fc.Advance(time.Minute)
+	recv(t, started)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, s.Stop(ctx))
+	assert.Equal(t, "canceled", recv(t, canceled))
+
+	// 未启动时停止
+	assert.Nil(t, NewScheduler(Options{}).Stop(context.Background()))
+}
+
+func TestSchedulerJitter(t *testing.T) {
+	fc := clock.NewFake(start)
+	s := NewScheduler(Options{Clock: fc})
+	id, _ := s.AddFunc("0 * * * *", func(ctx context.Context) error { return nil }, JobOptions{Jitter: 30 * time.Second})
+	s.Start()
+
+	planned := time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC)
+	e, _ := s.Entry(id)
+	assert.False(t, e.Next.Before(planned))
+	assert.True(t, e.Next.Before(planned.Add(30*time.Second)))
+
+	// 随机延迟不累积
+	fc.BlockUntil(1)
+	fc.Advance(e.Next.Sub(start))
+	fc.BlockUntil(1)
+	e, _ = s.Entry(id)
+	assert.False(t, e.Next.Before(planned.Add(time.Hour)))
+	assert.True(t, e.Next.Before(planned.Add(time.Hour+30*time.Second)))
+	assert.Nil(t, s.Stop(context.Background()))
+}
+
+func TestSchedulerJitterSeeded(t *testing.T) {
+	// 相同种子的随机延迟相同
+	next := func(seed uint64) time.Time {
+		s := NewScheduler(Options{Clock: clock.NewFake(start), Rand: random.New(random.NewSeeded(seed))})
+		id, _ := s.AddFunc("0 * * * *", func(ctx context.Context) error { return nil }, JobOptions{Jitter: time.Hour})
+		s.Start()
+		defer s.Stop(context.Background())
+		e, _ := s.Entry(id)
+		return e.Next
+	}
+	assert.Equal(t, next(1), next(1))
+	assert.NotEqual(t, next(1), next(2))
+}
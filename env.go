@@ -0,0 +1,199 @@
+package gu
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// EnvSource 环境变量来源，Lookup 返回变量值以及变量是否存在
+type EnvSource interface {
+	Lookup(name string) (string, bool)
+}
+
+// OSEnv 读取进程环境变量，即 os.LookupEnv
+type OSEnv struct{}
+
+func (OSEnv) Lookup(name string) (string, bool) {
+	return os.LookupEnv(name)
+}
+
+// MapEnv 基于 map 的环境变量来源，适用于测试注入
+type MapEnv map[string]string
+
+func (m MapEnv) Lookup(name string) (string, bool) {
+	value, ok := m[name]
+	return value, ok
+}
+
+// LayeredEnv 多层环境变量来源，按顺序查找，先找到的优先
+type LayeredEnv []EnvSource
+
+func (l LayeredEnv) Lookup(name string) (string, bool) {
+	for _, src := range l {
+		if src == nil {
+			continue
+		}
+		if value, ok := src.Lookup(name); ok {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// FileEnv 读取 dotenv 格式文件(KEY=VALUE)，返回 MapEnv
+//
+// 支持 # 注释、export 前缀、单双引号包裹的值，双引号内支持 \n \t \" \\ 转义
+func FileEnv(path string) (MapEnv, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseEnv(f)
+}
+
+// ParseEnv 从 reader 中解析 dotenv 格式内容
+func ParseEnv(r io.Reader) (MapEnv, error) {
+	env := MapEnv{}
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "export ") {
+			line = strings.TrimSpace(line[len("export "):])
+		}
+
+		pos := strings.Index(line, "=")
+		if pos <= 0 {
+			return nil, fmt.Errorf("gu.ParseEnv() Error: invalid line %d: %q", lineNo, line)
+		}
+
+		key := strings.TrimSpace(line[:pos])
+		value, err := parseEnvValue(strings.TrimSpace(line[pos+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("gu.ParseEnv() Error: line %d: %s", lineNo, err.Error())
+		}
+		env[key] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return env, nil
+}
+
+func parseEnvValue(raw string) (string, error) {
+	if raw == "" {
+		return "", nil
+	}
+
+	switch raw[0] {
+	case '\'':
+		end := strings.Index(raw[1:], "'")
+		if end < 0 {
+			return "", fmt.Errorf("unterminated quote")
+		}
+		return raw[1 : end+1], nil
+	case '"':
+		var sb strings.Builder
+		for i := 1; i < len(raw); i++ {
+			c := raw[i]
+			if c == '"' {
+				return sb.String(), nil
+			}
+			if c == '\\' && i+1 < len(raw) {
+				i++
+				switch raw[i] {
+				case 'n':
+					sb.WriteByte('\n')
+				case 't':
+					sb.WriteByte('\t')
+				case 'r':
+					sb.WriteByte('\r')
+				default:
+					sb.WriteByte(raw[i])
+				}
+				continue
+			}
+			sb.WriteByte(c)
+		}
+		return "", fmt.Errorf("unterminated quote")
+	}
+
+	// 未加引号的值，移除行尾注释
+	if pos := strings.Index(raw, " #"); pos >= 0 {
+		raw = raw[:pos]
+	}
+	return strings.TrimSpace(raw), nil
+}
+
+var (
+	envMu     sync.RWMutex
+	envSource EnvSource = OSEnv{}
+)
+
+// SetEnvSource 设置 Env/EnvInt 使用的环境变量来源，传入 nil 时恢复为 OSEnv
+//
+// 返回之前的来源，便于测试结束后还原
+func SetEnvSource(src EnvSource) EnvSource {
+	if src == nil {
+		src = OSEnv{}
+	}
+
+	envMu.Lock()
+	defer envMu.Unlock()
+	prev := envSource
+	envSource = src
+	return prev
+}
+
+// GetEnvSource 返回当前 Env/EnvInt 使用的环境变量来源
+func GetEnvSource() EnvSource {
+	envMu.RLock()
+	defer envMu.RUnlock()
+	return envSource
+}
+
+type envCtxKey struct{}
+
+// WithEnvSource 返回携带指定环境变量来源的 context，供 EnvCtx/EnvIntCtx 使用
+func WithEnvSource(ctx context.Context, src EnvSource) context.Context {
+	return context.WithValue(ctx, envCtxKey{}, src)
+}
+
+// EnvSourceFrom 返回 context 中的环境变量来源，不存在时返回全局来源
+func EnvSourceFrom(ctx context.Context) EnvSource {
+	if ctx != nil {
+		if src, ok := ctx.Value(envCtxKey{}).(EnvSource); ok && src != nil {
+			return src
+		}
+	}
+	return GetEnvSource()
+}
+
+// 查找环境变量，返回变量值以及变量是否存在
+func LookupEnv(name string) (string, bool) {
+	return GetEnvSource().Lookup(name)
+}
+
+// 从 context 指定的来源获取环境变量
+func EnvCtx(ctx context.Context, name string) string {
+	value, _ := EnvSourceFrom(ctx).Lookup(name)
+	return value
+}
+
+// 从 context 指定的来源获取环境变量，返回int, 发生错误时返回指定默认值
+func EnvIntCtx(ctx context.Context, name string, defaultValue int) int {
+	return St.Int(EnvCtx(ctx, name), defaultValue)
+}
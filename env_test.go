@@ -0,0 +1,78 @@
+package gu
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvSource(t *testing.T) {
+	prev := SetEnvSource(MapEnv{"PORT": "8080", "NAME": "gu"})
+	defer SetEnvSource(prev)
+
+	assert.Equal(t, "8080", Env("PORT"))
+	assert.Equal(t, 8080, EnvInt("PORT", -1))
+	assert.Equal(t, -1, EnvInt("NAME", -1))
+	assert.Equal(t, "", Env("MISSING"))
+
+	_, ok := LookupEnv("MISSING")
+	assert.False(t, ok)
+}
+
+func TestEnvCtx(t *testing.T) {
+	ctx := WithEnvSource(context.Background(), MapEnv{"PORT": "9090"})
+	assert.Equal(t, "9090", EnvCtx(ctx, "PORT"))
+	assert.Equal(t, 9090, EnvIntCtx(ctx, "PORT", -1))
+
+	// 未设置来源时使用全局来源
+	prev := SetEnvSource(MapEnv{"GU_ENV_CTX_TEST": "YES"})
+	t.Cleanup(func() { SetEnvSource(prev) })
+	assert.Equal(t, "YES", EnvCtx(context.Background(), "GU_ENV_CTX_TEST"))
+}
+
+func TestLayeredEnv(t *testing.T) {
+	env := LayeredEnv{MapEnv{"A": "1"}, nil, MapEnv{"A": "2", "B": "3"}}
+
+	a, _ := env.Lookup("A")
+	b, _ := env.Lookup("B")
+	_, ok := env.Lookup("C")
+	assert.Equal(t, "1", a)
+	assert.Equal(t, "3", b)
+	assert.False(t, ok)
+}
+
+func TestParseEnv(t *testing.T) {
+	content := `
+# comment
+export A=1
+B = hello world # trailing
+C="line\nbreak"
+D='raw\n'
+E=
+`
+	env, err := ParseEnv(strings.NewReader(content))
+	assert.Nil(t, err)
+	assert.Equal(t, MapEnv{"A": "1", "B": "hello world", "C": "line\nbreak", "D": `raw\n`, "E": ""}, env)
+
+	_, err = ParseEnv(strings.NewReader("INVALID"))
+	assert.NotNil(t, err)
+
+	_, err = ParseEnv(strings.NewReader(`A="open`))
+	assert.NotNil(t, err)
+}
+
+func TestFileEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	assert.Nil(t, os.WriteFile(path, []byte("HOST=localhost\n"), 0o644))
+
+	env, err := FileEnv(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "localhost", env["HOST"])
+
+	_, err = FileEnv(filepath.Join(t.TempDir(), "missing.env"))
+	assert.NotNil(t, err)
+}
@@ -1,8 +1,6 @@
 package gu
 
 import (
-	"os"
-
 	"github.com/arnoluo/gu/types"
 )
 
@@ -25,6 +23,9 @@ var (
 	// AnyType
 	At types.AnyType
 
+	// TimeType
+	Tt types.TimeType
+
 	// // ListType
 	// Lt types.ListType
 
@@ -35,9 +36,10 @@ var (
 	// Ht types.HeapType
 )
 
-// 获取环境变量
+// 获取环境变量，来源可通过 SetEnvSource 替换，默认为进程环境变量
 func Env(name string) string {
-	return os.Getenv(name)
+	value, _ := LookupEnv(name)
+	return value
 }
 
 // 获取环境变量，返回int, 发生错误时返回指定默认值
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"os"
 	"testing"
-	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -67,7 +66,7 @@ func TestStructTo(t *testing.T) {
 }
 
 func TestUtils(t *testing.T) {
-	ti := time.Now().UnixMilli()
+	ti := Tt.Now().UnixMilli()
 	fmt.Printf("Current time : %d\n", ti)
 
 	os.Setenv("TEST", "YES")
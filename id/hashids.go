@@ -0,0 +1,249 @@
+package id
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/arnoluo/gu/types"
+)
+
+const (
+	hashidsMinAlphabet = 16
+	hashidsSepDiv      = 3.5
+	hashidsGuardDiv    = 12
+	hashidsSeps        = "cfhistuCFHISTU"
+)
+
+// HashidsOptions Hashids 编码器选项
+type HashidsOptions struct {
+	// 字母表，默认 types.CHARS，至少 16 个不重复的 ASCII 字符且不能包含空格
+	Alphabet string
+	// 盐值，不同盐值得到不同的编码结果
+	Salt string
+	// 编码结果的最小长度
+	MinLength int
+}
+
+// Hashids 将 uint64 可逆地编码为短字符串，与 hashids.org 的算法兼容，可安全地并发使用
+//
+// 仅用于混淆 ID，不是加密算法
+type Hashids struct {
+	alphabet  string
+	salt      string
+	seps      string
+	guards    string
+	minLength int
+}
+
+// NewHashids 创建 Hashids 编码器
+func NewHashids(opts HashidsOptions) (*Hashids, error) {
+	if opts.Alphabet == "" {
+		opts.Alphabet = types.CHARS
+	}
+	if opts.MinLength < 0 {
+		return nil, fmt.Errorf("id: invalid hashids min length %d", opts.MinLength)
+	}
+
+	var alphabet []byte
+	var seen [256]bool
+	for i := 0; i < len(opts.Alphabet); i++ {
+		c := opts.Alphabet[i]
+		if c == ' ' || c >= 0x80 {
+			return nil, fmt.Errorf("id: invalid hashids alphabet character %q", c)
+		}
+		if !seen[c] {
+			seen[c] = true
+			alphabet = append(alphabet, c)
+		}
+	}
+	if len(alphabet) < hashidsMinAlphabet {
+		return nil, fmt.Errorf("id: hashids alphabet must contain at least %d unique characters", hashidsMinAlphabet)
+	}
+
+	// 分隔符只保留字母表中存在的字符，并从字母表中移除
+	var seps []byte
+	for i := 0; i < len(hashidsSeps); i++ {
+		if seen[hashidsSeps[i]] {
+			seps = append(seps, hashidsSeps[i])
+		}
+	}
+	alphabet = []byte(strings.Map(func(r rune) rune {
+		if strings.ContainsRune(string(seps), r) {
+			return -1
+		}
+		return r
+	}, string(alphabet)))
+	consistentShuffle(seps, opts.Salt)
+
+	if len(seps) == 0 || float64(len(alphabet))/float64(len(seps)) > hashidsSepDiv {
+		sepsLength := int(math.Ceil(float64(len(alphabet)) / hashidsSepDiv))
+		if sepsLength == 1 {
+			sepsLength++
+		}
+		if sepsLength > len(seps) {
+			diff := sepsLength - len(seps)
+			seps = append(seps, alphabet[:diff]...)
+			alphabet = alphabet[diff:]
+		} else {
+			seps = seps[:sepsLength]
+		}
+	}
+	consistentShuffle(alphabet, opts.Salt)
+
+	var guards []byte
+	guardCount := int(math.Ceil(float64(len(alphabet)) / hashidsGuardDiv))
+	if len(alphabet) < 3 {
+		guards, seps = seps[:guardCount], seps[guardCount:]
+	} else {
+		guards, alphabet = alphabet[:guardCount], alphabet[guardCount:]
+	}
+
+	return &Hashids{
+		alphabet:  string(alphabet),
+		salt:      opts.Salt,
+		seps:      string(seps),
+		guards:    string(guards),
+		minLength: opts.MinLength,
+	}, nil
+}
+
+// Encode 将一个或多个数字编码为字符串，未传入数字时返回空字符串
+func (h *Hashids) Encode(nums ...uint64) string {
+	if len(nums) == 0 {
+		return ""
+	}
+
+	alphabet := []byte(h.alphabet)
+	var numsHash uint64
+	for i, n := range nums {
+		numsHash += n % uint64(i+100)
+	}
+
+	lottery := alphabet[numsHash%uint64(len(alphabet))]
+	ret := []byte{lottery}
+	buf := make([]byte, 0, 1+len(h.salt)+len(alphabet))
+	for i, n := range nums {
+		buf = append(append(append(buf[:0], lottery), h.salt...), alphabet...)
+		consistentShuffle(alphabet, string(buf[:len(alphabet)]))
+		last := toAlphabet(n, alphabet)
+		ret = append(ret, last...)
+		if i+1 < len(nums) {
+			n %= uint64(last[0]) + uint64(i)
+			ret = append(ret, h.seps[n%uint64(len(h.seps))])
+		}
+	}
+
+	if len(ret) < h.minLength {
+		guard := h.guards[(numsHash+uint64(ret[0]))%uint64(len(h.guards))]
+		ret = append([]byte{guard}, ret...)
+		if len(ret) < h.minLength {
+			guard = h.guards[(numsHash+uint64(ret[2]))%uint64(len(h.guards))]
+			ret = append(ret, guard)
+		}
+	}
+
+	half := len(alphabet) / 2
+	for len(ret) < h.minLength {
+		consistentShuffle(alphabet, string(alphabet))
+		ret = append(append(append([]byte{}, alphabet[half:]...), ret...), alphabet[:half]...)
+		if excess := len(ret) - h.minLength; excess > 0 {
+			ret = ret[excess/2 : excess/2+h.minLength]
+		}
+	}
+	return string(ret)
+}
+
+// Decode 解码 Encode 生成的字符串，盐值、字母表不匹配或字符串被篡改时返回错误
+func (h *Hashids) Decode(s string) ([]uint64, error) {
+	if s == "" {
+		return nil, errors.New("id: empty hashid")
+	}
+
+	parts := strings.Split(replaceAny(s, h.guards), " ")
+	body := parts[0]
+	if len(parts) == 2 || len(parts) == 3 {
+		body = parts[1]
+	}
+	if body == "" {
+		return nil, fmt.Errorf("id: invalid hashid %q", s)
+	}
+
+	alphabet := []byte(h.alphabet)
+	lottery := body[0]
+	var nums []uint64
+	buf := make([]byte, 0, 1+len(h.salt)+len(alphabet))
+	for _, sub := range strings.Split(replaceAny(body[1:], h.seps), " ") {
+		buf = append(append(append(buf[:0], lottery), h.salt...), alphabet...)
+		consistentShuffle(alphabet, string(buf[:len(alphabet)]))
+		n, ok := fromAlphabet(sub, alphabet)
+		if !ok {
+			return nil, fmt.Errorf("id: invalid hashid %q", s)
+		}
+		nums = append(nums, n)
+	}
+
+	// 重新编码校验，防止篡改或盐值不匹配
+	if h.Encode(nums...) != s {
+		return nil, fmt.Errorf("id: invalid hashid %q", s)
+	}
+	return nums, nil
+}
+
+// 按盐值确定性地打乱字母表
+func consistentShuffle(alphabet []byte, salt string) {
+	if salt == "" {
+		return
+	}
+	for i, v, p := len(alphabet)-1, 0, 0; i > 0; i, v = i-1, v+1 {
+		v %= len(salt)
+		c := int(salt[v])
+		p += c
+		j := (c + v + p) % i
+		alphabet[i], alphabet[j] = alphabet[j], alphabet[i]
+	}
+}
+
+func toAlphabet(n uint64, alphabet []byte) []byte {
+	var buf [64]byte
+	i := len(buf)
+	base := uint64(len(alphabet))
+	for {
+		i--
+		buf[i] = alphabet[n%base]
+		n /= base
+		if n == 0 {
+			break
+		}
+	}
+	return append([]byte(nil), buf[i:]...)
+}
+
+func fromAlphabet(s string, alphabet []byte) (uint64, bool) {
+	if s == "" {
+		return 0, false
+	}
+	base := uint64(len(alphabet))
+	var n uint64
+	for i := 0; i < len(s); i++ {
+		pos := strings.IndexByte(string(alphabet), s[i])
+		if pos < 0 {
+			return 0, false
+		}
+		if n > (math.MaxUint64-uint64(pos))/base {
+			return 0, false
+		}
+		n = n*base + uint64(pos)
+	}
+	return n, true
+}
+
+func replaceAny(s, chars string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(chars, r) {
+			return ' '
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,73 @@
+package id
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashids(t *testing.T) {
+	// hashids.org 参考实现的结果
+	h, err := NewHashids(HashidsOptions{
+		Alphabet: "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ1234567890",
+		Salt:     "this is my salt",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "NkK9", h.Encode(12345))
+	assert.Equal(t, "laHquq", h.Encode(1, 2, 3))
+	nums, err := h.Decode("laHquq")
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{1, 2, 3}, nums)
+
+	h, _ = NewHashids(HashidsOptions{
+		Alphabet:  "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ1234567890",
+		Salt:      "this is my salt",
+		MinLength: 8,
+	})
+	assert.Equal(t, "gB0NV05e", h.Encode(1))
+
+	assert.Equal(t, "", h.Encode())
+}
+
+func TestHashidsRoundTrip(t *testing.T) {
+	h, err := NewHashids(HashidsOptions{Salt: "gu", MinLength: 6})
+	assert.Nil(t, err)
+	for _, nums := range [][]uint64{{0}, {1}, {42}, {1, 2, 3}, {math.MaxUint64}, {math.MaxUint64, 0, 7}} {
+		s := h.Encode(nums...)
+		assert.True(t, len(s) >= 6, s)
+		got, err := h.Decode(s)
+		assert.Nil(t, err, s)
+		assert.Equal(t, nums, got)
+	}
+
+	// 默认字母表为 types.CHARS
+	for _, c := range h.Encode(123456789) {
+		assert.NotContains(t, "0ilo", string(c))
+	}
+
+	// 不同盐值结果不同且互相无法解码
+	other, _ := NewHashids(HashidsOptions{Salt: "other", MinLength: 6})
+	s := h.Encode(100)
+	assert.NotEqual(t, s, other.Encode(100))
+	_, err = other.Decode(s)
+	assert.NotNil(t, err)
+
+	for _, bad := range []string{"", "0000", s + "x", "中文"} {
+		_, err = h.Decode(bad)
+		assert.NotNil(t, err, bad)
+	}
+}
+
+func TestHashidsOptions(t *testing.T) {
+	for _, opts := range []HashidsOptions{
+		{Alphabet: "abcdefg"},
+		{Alphabet: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"},
+		{Alphabet: "abcdefghijklmnop qrstuvwxyz"},
+		{Alphabet: "abcdefghijklmnopqrstuvwxyzé"},
+		{MinLength: -1},
+	} {
+		_, err := NewHashids(opts)
+		assert.NotNil(t, err, opts)
+	}
+}
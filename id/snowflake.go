@@ -0,0 +1,138 @@
+package id
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/arnoluo/gu/clock"
+)
+
+// ErrClockBackwards 时钟回拨超过容忍范围
+var ErrClockBackwards = errors.New("id: clock moved backwards")
+
+// DefaultEpoch Snowflake 默认起始时间 2020-01-01 00:00:00 UTC
+var DefaultEpoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// SnowflakeOptions Snowflake 生成器选项
+type SnowflakeOptions struct {
+	// 起始时间，默认 DefaultEpoch
+	Epoch time.Time
+	// 节点位数，0 表示默认值 10，负数表示不使用节点位(单节点，Node 只能为 0)
+	NodeBits int
+	// 序列号位数，0 表示默认值 12
+	SeqBits int
+	// 节点 ID，取值 [0, 2^NodeBits)
+	Node int64
+	// 时钟回拨容忍时长，回拨不超过该值时沿用上一次的时间戳继续生成，否则返回 ErrClockBackwards
+	MaxBackwards time.Duration
+	// 时钟，默认 clock.Real
+	Clock clock.Clock
+}
+
+// Snowflake 64 位趋势递增 ID 生成器: 1 位符号 + 时间戳(毫秒) + 节点 + 序列号
+type Snowflake struct {
+	epoch        int64
+	nodeBits     uint8
+	seqBits      uint8
+	timeBits     uint8
+	node         int64
+	maxBackwards int64
+	clock        clock.Clock
+
+	mu   sync.Mutex
+	last int64
+	seq  int64
+}
+
+// SnowflakeID 分解后的 Snowflake ID
+type SnowflakeID struct {
+	Time time.Time
+	Node int64
+	Seq  int64
+}
+
+// NewSnowflake 创建 Snowflake 生成器，NodeBits + SeqBits 不能超过 22(时间戳至少 41 位)
+func NewSnowflake(opts SnowflakeOptions) (*Snowflake, error) {
+	if opts.Epoch.IsZero() {
+		opts.Epoch = DefaultEpoch
+	}
+	switch {
+	case opts.NodeBits == 0:
+		opts.NodeBits = 10
+	case opts.NodeBits < 0:
+		opts.NodeBits = 0
+	}
+	if opts.SeqBits == 0 {
+		opts.SeqBits = 12
+	}
+	if opts.Clock == nil {
+		opts.Clock = clock.Real
+	}
+	if opts.SeqBits < 0 || opts.NodeBits+opts.SeqBits > 22 {
+		return nil, fmt.Errorf("id: invalid snowflake bits node=%d seq=%d", opts.NodeBits, opts.SeqBits)
+	}
+	if opts.Node < 0 || opts.Node >= 1<<opts.NodeBits {
+		return nil, fmt.Errorf("id: snowflake node %d out of range [0, %d)", opts.Node, int64(1)<<opts.NodeBits)
+	}
+	if opts.Epoch.After(opts.Clock.Now()) {
+		return nil, errors.New("id: snowflake epoch is in the future")
+	}
+
+	return &Snowflake{
+		epoch:        opts.Epoch.UnixMilli(),
+		nodeBits:     uint8(opts.NodeBits),
+		seqBits:      uint8(opts.SeqBits),
+		timeBits:     uint8(63 - opts.NodeBits - opts.SeqBits),
+		node:         opts.Node,
+		maxBackwards: opts.MaxBackwards.Milliseconds(),
+		clock:        opts.Clock,
+		last:         -1,
+	}, nil
+}
+
+// Next 生成下一个 ID，同一毫秒内序列号耗尽时等待下一毫秒
+func (s *Snowflake) Next() (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.since()
+	if now < s.last {
+		if s.last-now > s.maxBackwards {
+			return 0, fmt.Errorf("%w by %dms", ErrClockBackwards, s.last-now)
+		}
+		now = s.last
+	}
+
+	if now == s.last {
+		s.seq = (s.seq + 1) & (1<<s.seqBits - 1)
+		if s.seq == 0 {
+			for now <= s.last {
+				s.clock.Sleep(time.Millisecond)
+				now = s.since()
+			}
+		}
+	} else {
+		s.seq = 0
+	}
+
+	if now >= 1<<s.timeBits {
+		return 0, errors.New("id: snowflake timestamp overflow")
+	}
+	s.last = now
+	return now<<(s.nodeBits+s.seqBits) | s.node<<s.seqBits | s.seq, nil
+}
+
+func (s *Snowflake) since() int64 {
+	return s.clock.Now().UnixMilli() - s.epoch
+}
+
+// Decompose 将 ID 分解为时间、节点与序列号
+func (s *Snowflake) Decompose(id int64) SnowflakeID {
+	return SnowflakeID{
+		Time: time.UnixMilli(id>>(s.nodeBits+s.seqBits) + s.epoch),
+		Node: id >> s.seqBits & (1<<s.nodeBits - 1),
+		Seq:  id & (1<<s.seqBits - 1),
+	}
+}
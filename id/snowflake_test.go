@@ -0,0 +1,145 @@
+package id
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/arnoluo/gu/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnowflake(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(now)
+	sf, err := NewSnowflake(SnowflakeOptions{Node: 5, Clock: fake})
+	assert.Nil(t, err)
+
+	id1, err := sf.Next()
+	assert.Nil(t, err)
+	id2, _ := sf.Next()
+	assert.True(t, id2 > id1)
+
+	parts := sf.Decompose(id2)
+	assert.True(t, parts.Time.Equal(now))
+	assert.Equal(t, int64(5), parts.Node)
+	assert.Equal(t, int64(1), parts.Seq)
+
+	fake.Advance(time.Millisecond)
+	id3, _ := sf.Next()
+	assert.True(t, id3 > id2)
+	assert.Equal(t, int64(0), sf.Decompose(id3).Seq)
+}
+
+func TestSnowflakeSeqExhausted(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(now)
+	sf, err := NewSnowflake(SnowflakeOptions{NodeBits: 2, SeqBits: 2, Node: 3, Clock: fake})
+	assert.Nil(t, err)
+
+	for i := 0; i < 4; i++ {
+		id, err := sf.Next()
+		assert.Nil(t, err)
+		assert.Equal(t, int64(i), sf.Decompose(id).Seq)
+	}
+
+	// 序列号耗尽时等待下一毫秒
+	done := make(chan int64)
+	go func() {
+		id, _ := sf.Next()
+		done <- id
+	}()
+	fake.BlockUntil(1)
+	fake.Advance(time.Millisecond)
+	parts := sf.Decompose(<-done)
+	assert.True(t, parts.Time.Equal(now.Add(time.Millisecond)))
+	assert.Equal(t, int64(3), parts.Node)
+	assert.Equal(t, int64(0), parts.Seq)
+}
+
+func TestSnowflakeClockBackwards(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(now)
+	sf, _ := NewSnowflake(SnowflakeOptions{Clock: fake, MaxBackwards: 10 * time.Millisecond})
+
+	id1, _ := sf.Next()
+	// 容忍范围内沿用上一次的时间戳
+	fake.Set(now.Add(-5 * time.Millisecond))
+	id2, err := sf.Next()
+	assert.Nil(t, err)
+	assert.True(t, id2 > id1)
+	assert.True(t, sf.Decompose(id2).Time.Equal(now))
+
+	fake.Set(now.Add(-time.Second))
+	_, err = sf.Next()
+	assert.True(t, errors.Is(err, ErrClockBackwards))
+
+	fake.Set(now.Add(time.Millisecond))
+	id3, err := sf.Next()
+	assert.Nil(t, err)
+	assert.True(t, id3 > id2)
+}
+
+func TestSnowflakeOptions(t *testing.T) {
+	for _, opts := range []SnowflakeOptions{
+		{NodeBits: 12, SeqBits: 12},
+		{NodeBits: 16},
+		{SeqBits: 13},
+		{SeqBits: -1},
+		{Node: 1024},
+		{Node: -1},
+		{Epoch: time.Now().Add(time.Hour)},
+	} {
+		_, err := NewSnowflake(opts)
+		assert.NotNil(t, err, opts)
+	}
+
+	// 未设置的位数各自使用默认值
+	sf, err := NewSnowflake(SnowflakeOptions{NodeBits: 8})
+	assert.Nil(t, err)
+	assert.Equal(t, uint8(12), sf.seqBits)
+	sf, err = NewSnowflake(SnowflakeOptions{SeqBits: 8, Node: 1023})
+	assert.Nil(t, err)
+	assert.Equal(t, uint8(10), sf.nodeBits)
+
+	// 不使用节点位，全部用于序列号
+	sf, err = NewSnowflake(SnowflakeOptions{NodeBits: -1, SeqBits: 22})
+	assert.Nil(t, err)
+	assert.Equal(t, uint8(0), sf.nodeBits)
+	n, err := sf.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, SnowflakeID{Time: sf.Decompose(n).Time, Node: 0, Seq: 0}, sf.Decompose(n))
+	_, err = NewSnowflake(SnowflakeOptions{NodeBits: -1, Node: 1})
+	assert.NotNil(t, err)
+
+	epoch := time.Date(2010, 11, 4, 1, 42, 54, 657000000, time.UTC)
+	sf, err = NewSnowflake(SnowflakeOptions{Epoch: epoch, NodeBits: 10, SeqBits: 12})
+	assert.Nil(t, err)
+	parts := sf.Decompose(1288834974657<<22 | 7<<12 | 9)
+	assert.Equal(t, int64(1288834974657)+epoch.UnixMilli(), parts.Time.UnixMilli())
+	assert.Equal(t, int64(7), parts.Node)
+	assert.Equal(t, int64(9), parts.Seq)
+}
+
+func TestSnowflakeConcurrent(t *testing.T) {
+	sf, _ := NewSnowflake(SnowflakeOptions{Node: 1})
+	var mu sync.Mutex
+	seen := map[int64]bool{}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 2000; j++ {
+				id, err := sf.Next()
+				assert.Nil(t, err)
+				mu.Lock()
+				seen[id] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, seen, 16000)
+}
@@ -0,0 +1,168 @@
+package id
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ULID 48 位毫秒时间戳 + 80 位随机数，字符串为 26 位 Crockford Base32
+type ULID [16]byte
+
+const ulidAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// Crockford Base32 解码表，兼容小写以及 I/L => 1、O => 0
+var ulidDecode [256]byte
+
+func init() {
+	for i := range ulidDecode {
+		ulidDecode[i] = 0xff
+	}
+	for i := 0; i < len(ulidAlphabet); i++ {
+		c := ulidAlphabet[i]
+		ulidDecode[c] = byte(i)
+		if c >= 'A' && c <= 'Z' {
+			ulidDecode[c+'a'-'A'] = byte(i)
+		}
+	}
+	for _, c := range "IiLl" {
+		ulidDecode[c] = 1
+	}
+	ulidDecode['O'], ulidDecode['o'] = 0, 0
+}
+
+// NewULID 生成 ULID
+func NewULID() ULID {
+	return defaultGenerator.ULID()
+}
+
+// NewMonotonicULID 生成单调 ULID，同一进程内严格递增
+func NewMonotonicULID() ULID {
+	return defaultGenerator.MonotonicULID()
+}
+
+// ULID 生成 ULID，同一毫秒内的顺序不保证
+func (g *Generator) ULID() ULID {
+	var u ULID
+	setULIDTime(&u, g.clock.Now().UnixMilli())
+	g.random().Read(u[6:])
+	return u
+}
+
+// MonotonicULID 生成单调 ULID，同一毫秒内随机部分加 1，溢出时借用下一毫秒
+func (g *Generator) MonotonicULID() ULID {
+	var u ULID
+	var entropy [10]byte
+	g.random().Read(entropy[:])
+
+	g.mu.Lock()
+	ms := g.clock.Now().UnixMilli()
+	if ms > g.ulidMs {
+		g.ulidMs = ms
+		g.ulidRand = entropy
+	} else if !incrementBytes(g.ulidRand[:]) {
+		g.ulidMs++
+	}
+	setULIDTime(&u, g.ulidMs)
+	copy(u[6:], g.ulidRand[:])
+	g.mu.Unlock()
+	return u
+}
+
+// 大端字节数组加 1，溢出时返回 false
+func incrementBytes(b []byte) bool {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func setULIDTime(u *ULID, ms int64) {
+	for i := 0; i < 6; i++ {
+		u[i] = byte(ms >> (40 - 8*i))
+	}
+}
+
+// ParseULID 解析 26 位 ULID 字符串，不区分大小写
+func ParseULID(s string) (ULID, error) {
+	var u ULID
+	if len(s) != 26 {
+		return u, fmt.Errorf("id: invalid ULID length %d in %q", len(s), s)
+	}
+	// 26 * 5 = 130 位，首字符最大为 7 以免溢出 128 位
+	if v := ulidDecode[s[0]]; v > 7 {
+		return u, fmt.Errorf("id: invalid ULID %q", s)
+	}
+
+	bit := -2
+	for i := 0; i < len(s); i++ {
+		v := ulidDecode[s[i]]
+		if v == 0xff {
+			return ULID{}, fmt.Errorf("id: invalid ULID character %q in %q", s[i], s)
+		}
+		for j := 4; j >= 0; j-- {
+			if bit >= 0 && v>>uint(j)&1 == 1 {
+				u[bit/8] |= 0x80 >> uint(bit%8)
+			}
+			bit++
+		}
+	}
+	return u, nil
+}
+
+// MustParseULID 同 ParseULID，解析失败时 panic
+func MustParseULID(s string) ULID {
+	u, err := ParseULID(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// String 返回 26 位 Crockford Base32 字符串
+func (u ULID) String() string {
+	var buf [26]byte
+	bit := -2
+	for i := range buf {
+		var v byte
+		for j := 0; j < 5; j++ {
+			v <<= 1
+			if bit >= 0 && u[bit/8]&(0x80>>uint(bit%8)) != 0 {
+				v |= 1
+			}
+			bit++
+		}
+		buf[i] = ulidAlphabet[v]
+	}
+	return string(buf[:])
+}
+
+// Time 返回 ULID 中的时间戳
+func (u ULID) Time() time.Time {
+	var ms int64
+	for i := 0; i < 6; i++ {
+		ms = ms<<8 | int64(u[i])
+	}
+	return time.UnixMilli(ms)
+}
+
+// MarshalText 实现 encoding.TextMarshaler
+func (u ULID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler
+func (u *ULID) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("id: empty ULID")
+	}
+	parsed, err := ParseULID(string(data))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
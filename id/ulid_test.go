@@ -0,0 +1,63 @@
+package id
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arnoluo/gu/clock"
+	"github.com/arnoluo/gu/random"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestULID(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	g := NewGenerator(clock.NewFake(now), random.New(random.NewSeeded(1)))
+
+	u := g.ULID()
+	s := u.String()
+	assert.Len(t, s, 26)
+	assert.True(t, u.Time().Equal(now))
+	assert.Equal(t, u, MustParseULID(s))
+	assert.NotEqual(t, u, NewULID())
+
+	// 单调模式同一毫秒内严格递增
+	prev := g.MonotonicULID()
+	for i := 0; i < 1000; i++ {
+		next := g.MonotonicULID()
+		assert.True(t, prev.String() < next.String())
+		assert.True(t, next.Time().Equal(now))
+		prev = next
+	}
+
+	// 随机部分溢出时借用下一毫秒
+	for i := range g.ulidRand {
+		g.ulidRand[i] = 0xff
+	}
+	next := g.MonotonicULID()
+	assert.True(t, next.Time().Equal(now.Add(time.Millisecond)))
+	assert.True(t, prev.String() < next.String())
+
+	assert.True(t, NewMonotonicULID().String() < NewMonotonicULID().String())
+}
+
+func TestParseULID(t *testing.T) {
+	u := MustParseULID("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	assert.Equal(t, int64(1469922850259), u.Time().UnixMilli())
+	assert.Equal(t, "01ARZ3NDEKTSV4RRFFQ69G5FAV", u.String())
+
+	// 不区分大小写，I/L/O 按 Crockford 规则映射
+	assert.Equal(t, u, MustParseULID("01arz3ndektsv4rrffq69g5fav"))
+	assert.Equal(t, MustParseULID("01ARZ3NDEKTSV4RRFFQ69G5F10"), MustParseULID("OLARZ3NDEKTSV4RRFFQ69G5FIO"))
+
+	assert.Equal(t, "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", MustParseULID("7ZZZZZZZZZZZZZZZZZZZZZZZZZ").String())
+	for _, s := range []string{"", "01ARZ3NDEKTSV4RRFFQ69G5FA", "81ARZ3NDEKTSV4RRFFQ69G5FAV", "01ARZ3NDEKTSV4RRFFQ69G5FAU"} {
+		_, err := ParseULID(s)
+		assert.NotNil(t, err, s)
+	}
+
+	var out ULID
+	assert.Nil(t, out.UnmarshalText([]byte("01ARZ3NDEKTSV4RRFFQ69G5FAV")))
+	assert.Equal(t, u, out)
+	text, _ := u.MarshalText()
+	assert.Equal(t, "01ARZ3NDEKTSV4RRFFQ69G5FAV", string(text))
+}
@@ -0,0 +1,191 @@
+// Package id 唯一 ID 生成：RFC 9562 UUID v4/v7、ULID 与 Snowflake，均可安全地并发使用
+package id
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arnoluo/gu/clock"
+	"github.com/arnoluo/gu/random"
+)
+
+// UUID RFC 9562 UUID
+type UUID [16]byte
+
+// Nil 全零 UUID
+var Nil UUID
+
+// Generator UUID 与 ULID 生成器，时钟与随机数来源可替换以便测试
+type Generator struct {
+	clock clock.Clock
+	rand  *random.Rand
+
+	mu sync.Mutex
+	// UUIDv7 上一次的毫秒时间戳与 12 位计数器
+	v7ms  int64
+	v7seq uint16
+	// 单调 ULID 上一次的毫秒时间戳与随机部分
+	ulidMs   int64
+	ulidRand [10]byte
+}
+
+// NewGenerator 创建生成器，c 为 nil 时使用 clock.Real，r 为 nil 时使用 random.Default()
+func NewGenerator(c clock.Clock, r *random.Rand) *Generator {
+	if c == nil {
+		c = clock.Real
+	}
+	return &Generator{clock: c, rand: r}
+}
+
+var defaultGenerator = NewGenerator(nil, nil)
+
+func (g *Generator) random() *random.Rand {
+	if g.rand == nil {
+		return random.Default()
+	}
+	return g.rand
+}
+
+// NewV4 生成随机 UUID(版本 4)
+func NewV4() UUID {
+	return defaultGenerator.UUIDv4()
+}
+
+// NewV7 生成按时间排序的 UUID(版本 7)，同一进程内严格递增
+func NewV7() UUID {
+	return defaultGenerator.UUIDv7()
+}
+
+// UUIDv4 生成随机 UUID(版本 4)
+func (g *Generator) UUIDv4() UUID {
+	var u UUID
+	g.random().Read(u[:])
+	u[6] = u[6]&0x0f | 0x40
+	u[8] = u[8]&0x3f | 0x80
+	return u
+}
+
+// UUIDv7 生成按时间排序的 UUID(版本 7)
+//
+// 48 位毫秒时间戳后的 12 位 rand_a 作为计数器(RFC 9562 6.2 方法 1)，同一毫秒内递增，溢出时借用下一毫秒
+func (g *Generator) UUIDv7() UUID {
+	var u UUID
+	g.random().Read(u[:])
+
+	g.mu.Lock()
+	ms := g.clock.Now().UnixMilli()
+	if ms > g.v7ms {
+		// 新的毫秒从随机值开始，保留高位以留出递增空间
+		g.v7ms = ms
+		g.v7seq = uint16(u[6])<<8&0x700 | uint16(u[7])
+	} else if g.v7seq++; g.v7seq > 0xfff {
+		g.v7ms++
+		g.v7seq = 0
+	}
+	ms, seq := g.v7ms, g.v7seq
+	g.mu.Unlock()
+
+	for i := 0; i < 6; i++ {
+		u[i] = byte(ms >> (40 - 8*i))
+	}
+	u[6] = 0x70 | byte(seq>>8)
+	u[7] = byte(seq)
+	u[8] = u[8]&0x3f | 0x80
+	return u
+}
+
+// ParseUUID 解析 UUID，支持 xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx、32 位十六进制、{...} 与 urn:uuid: 前缀形式
+func ParseUUID(s string) (UUID, error) {
+	var u UUID
+	str := s
+	switch {
+	case len(str) == 45 && strings.EqualFold(str[:9], "urn:uuid:"):
+		str = str[9:]
+	case len(str) == 38 && str[0] == '{' && str[37] == '}':
+		str = str[1:37]
+	}
+
+	switch len(str) {
+	case 36:
+		if str[8] != '-' || str[13] != '-' || str[18] != '-' || str[23] != '-' {
+			return u, fmt.Errorf("id: invalid UUID %q", s)
+		}
+		str = str[:8] + str[9:13] + str[14:18] + str[19:23] + str[24:]
+	case 32:
+	default:
+		return u, fmt.Errorf("id: invalid UUID length %d in %q", len(s), s)
+	}
+
+	if _, err := hex.Decode(u[:], []byte(str)); err != nil {
+		return Nil, fmt.Errorf("id: invalid UUID %q", s)
+	}
+	return u, nil
+}
+
+// MustParseUUID 同 ParseUUID，解析失败时 panic
+func MustParseUUID(s string) UUID {
+	u, err := ParseUUID(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// String 返回 xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx 形式的小写字符串
+func (u UUID) String() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// Version 返回版本号
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// IsNil 判断是否为全零 UUID
+func (u UUID) IsNil() bool {
+	return u == Nil
+}
+
+// Time 返回版本 7 UUID 中的时间戳，其他版本返回零值
+func (u UUID) Time() time.Time {
+	if u.Version() != 7 {
+		return time.Time{}
+	}
+	var ms int64
+	for i := 0; i < 6; i++ {
+		ms = ms<<8 | int64(u[i])
+	}
+	return time.UnixMilli(ms)
+}
+
+// MarshalText 实现 encoding.TextMarshaler
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler
+func (u *UUID) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("id: empty UUID")
+	}
+	parsed, err := ParseUUID(string(data))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
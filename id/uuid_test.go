@@ -0,0 +1,110 @@
+package id
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/arnoluo/gu/clock"
+	"github.com/arnoluo/gu/random"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUUIDv4(t *testing.T) {
+	u := NewV4()
+	assert.Equal(t, 4, u.Version())
+	assert.Equal(t, byte(0x80), u[8]&0xc0)
+	assert.NotEqual(t, u, NewV4())
+	assert.True(t, u.Time().IsZero())
+
+	// 相同种子结果相同
+	a := NewGenerator(nil, random.New(random.NewSeeded(1))).UUIDv4()
+	b := NewGenerator(nil, random.New(random.NewSeeded(1))).UUIDv4()
+	assert.Equal(t, a, b)
+}
+
+func TestUUIDv7(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(now)
+	g := NewGenerator(fake, random.New(random.NewSeeded(1)))
+
+	u := g.UUIDv7()
+	assert.Equal(t, 7, u.Version())
+	assert.Equal(t, byte(0x80), u[8]&0xc0)
+	assert.True(t, u.Time().Equal(now))
+
+	// 同一毫秒内严格递增
+	prev := u
+	for i := 0; i < 5000; i++ {
+		next := g.UUIDv7()
+		assert.True(t, prev.String() < next.String())
+		prev = next
+	}
+	// 计数器溢出后借用下一毫秒
+	assert.True(t, prev.Time().After(now))
+
+	// 时钟回拨时仍递增
+	fake.Set(now.Add(-time.Second))
+	assert.True(t, prev.String() < g.UUIDv7().String())
+}
+
+func TestUUIDConcurrent(t *testing.T) {
+	var mu sync.Mutex
+	seen := map[UUID]bool{}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				u := NewV7()
+				mu.Lock()
+				seen[u] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, seen, 8000)
+}
+
+func TestParseUUID(t *testing.T) {
+	want := MustParseUUID("f81d4fae-7dec-11d0-a765-00a0c91e6bf6")
+	assert.Equal(t, "f81d4fae-7dec-11d0-a765-00a0c91e6bf6", want.String())
+	assert.Equal(t, 1, want.Version())
+
+	for _, s := range []string{
+		"F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6",
+		"f81d4fae7dec11d0a76500a0c91e6bf6",
+		"{f81d4fae-7dec-11d0-a765-00a0c91e6bf6}",
+		"urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+	} {
+		u, err := ParseUUID(s)
+		assert.Nil(t, err, s)
+		assert.Equal(t, want, u, s)
+	}
+
+	for _, s := range []string{
+		"",
+		"f81d4fae-7dec-11d0-a765-00a0c91e6bf",
+		"f81d4fae_7dec-11d0-a765-00a0c91e6bf6",
+		"g81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+		"{f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+	} {
+		_, err := ParseUUID(s)
+		assert.NotNil(t, err, s)
+	}
+	assert.Panics(t, func() { MustParseUUID("x") })
+
+	assert.True(t, Nil.IsNil())
+	assert.Equal(t, "00000000-0000-0000-0000-000000000000", Nil.String())
+
+	u := NewV4()
+	data, err := json.Marshal(map[string]UUID{"id": u})
+	assert.Nil(t, err)
+	var out map[string]UUID
+	assert.Nil(t, json.Unmarshal(data, &out))
+	assert.Equal(t, u, out["id"])
+	assert.NotNil(t, json.Unmarshal([]byte(`{"id":""}`), &out))
+}
@@ -1,6 +1,11 @@
 package gu
 
+import "github.com/arnoluo/gu/types"
+
 const (
-	Ymd    string = "2006-01-02"
-	YmdHis string = "2006-01-02 15:04:05"
+	Ymd      string = types.Ymd
+	Ym       string = types.Ym
+	His      string = types.His
+	YmdHis   string = types.YmdHis
+	YmdHisMs string = types.YmdHisMs
 )
@@ -0,0 +1,122 @@
+package random
+
+import (
+	"math/bits"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// Rand 基于 Source 的随机数生成器，可安全地并发使用
+type Rand struct {
+	src Source
+}
+
+// New 使用指定来源创建生成器，src 为 nil 时使用 NewSecure()
+func New(src Source) *Rand {
+	if src == nil {
+		src = NewSecure()
+	}
+	return &Rand{src: src}
+}
+
+var defaultRand atomic.Pointer[Rand]
+
+func init() {
+	defaultRand.Store(New(NewSecure()))
+}
+
+// Default 返回默认生成器，gu.St.Rand 等方法使用该生成器，默认来源为 crypto/rand
+func Default() *Rand {
+	return defaultRand.Load()
+}
+
+// SetDefault 设置默认生成器，传入 nil 时恢复为 crypto/rand 来源
+//
+// 返回之前的生成器，便于测试结束后还原
+func SetDefault(r *Rand) *Rand {
+	if r == nil {
+		r = New(NewSecure())
+	}
+	return defaultRand.Swap(r)
+}
+
+// Uint64 返回 [0, 2^64) 的随机数
+func (r *Rand) Uint64() uint64 {
+	return r.src.Uint64()
+}
+
+// Uint64n 返回 [0, n) 的无偏随机数，n 为 0 时 panic
+func (r *Rand) Uint64n(n uint64) uint64 {
+	if n == 0 {
+		panic("random: invalid argument to Uint64n")
+	}
+	if n&(n-1) == 0 {
+		return r.src.Uint64() & (n - 1)
+	}
+
+	// Lemire 乘法取高位，拒绝落在不完整区间的值以消除取模偏差
+	hi, lo := bits.Mul64(r.src.Uint64(), n)
+	if lo < n {
+		thresh := -n % n
+		for lo < thresh {
+			hi, lo = bits.Mul64(r.src.Uint64(), n)
+		}
+	}
+	return hi
+}
+
+// Intn 返回 [0, n) 的无偏随机数，n <= 0 时 panic
+func (r *Rand) Intn(n int) int {
+	if n <= 0 {
+		panic("random: invalid argument to Intn")
+	}
+	return int(r.Uint64n(uint64(n)))
+}
+
+// Read 以随机字节填充 p，始终返回 len(p), nil
+func (r *Rand) Read(p []byte) (int, error) {
+	for i := 0; i < len(p); {
+		v := r.src.Uint64()
+		for j := 0; j < 8 && i < len(p); j++ {
+			p[i] = byte(v)
+			v >>= 8
+			i++
+		}
+	}
+	return len(p), nil
+}
+
+// String 从 chars 中随机选取 length 个字符组成字符串，chars 可包含多字节字符，为空时返回空串
+func (r *Rand) String(chars string, length int) string {
+	if chars == "" || length <= 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	if isASCII(chars) {
+		sb.Grow(length)
+		n := uint64(len(chars))
+		for i := 0; i < length; i++ {
+			sb.WriteByte(chars[r.Uint64n(n)])
+		}
+		return sb.String()
+	}
+
+	runes := []rune(chars)
+	n := uint64(len(runes))
+	sb.Grow(length * utf8.UTFMax)
+	for i := 0; i < length; i++ {
+		sb.WriteRune(runes[r.Uint64n(n)])
+	}
+	return sb.String()
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
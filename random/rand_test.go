@@ -0,0 +1,87 @@
+package random
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeeded(t *testing.T) {
+	a, b := New(NewSeeded(42)), New(NewSeeded(42))
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, a.Uint64(), b.Uint64())
+	}
+	assert.NotEqual(t, New(NewSeeded(1)).Uint64(), New(NewSeeded(2)).Uint64())
+	assert.Equal(t, New(NewSeeded(7)).String(testChars, 16), New(NewSeeded(7)).String(testChars, 16))
+}
+
+const testChars = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+func TestSecure(t *testing.T) {
+	r := New(nil)
+	seen := map[uint64]bool{}
+	for i := 0; i < 1000; i++ {
+		seen[r.Uint64()] = true
+	}
+	assert.Equal(t, 1000, len(seen))
+}
+
+func TestUint64n(t *testing.T) {
+	r := New(NewSeeded(1))
+	// 3 不是 2 的幂，取模会产生偏差
+	for _, n := range []uint64{1, 2, 3, 10, 1 << 40, 1<<63 + 1} {
+		for i := 0; i < 1000; i++ {
+			assert.Less(t, r.Uint64n(n), n)
+		}
+	}
+
+	counts := make([]int, 6)
+	for i := 0; i < 60000; i++ {
+		counts[r.Intn(6)]++
+	}
+	for _, c := range counts {
+		assert.InDelta(t, 10000, c, 500)
+	}
+
+	assert.Panics(t, func() { r.Intn(0) })
+	assert.Panics(t, func() { r.Uint64n(0) })
+}
+
+func TestString(t *testing.T) {
+	r := New(NewSeeded(1))
+	assert.Equal(t, "ccc", r.String("c", 3))
+	assert.Equal(t, "", r.String("", 3))
+	assert.Equal(t, "", r.String("abc", 0))
+
+	s := r.String("abc", 1000)
+	assert.Len(t, s, 1000)
+	for _, c := range "abc" {
+		assert.Contains(t, s, string(c))
+	}
+
+	s = r.String("中文字符", 10)
+	assert.Equal(t, 10, utf8.RuneCountInString(s))
+	assert.Equal(t, 30, len(s))
+}
+
+func TestRead(t *testing.T) {
+	buf := make([]byte, 13)
+	n, err := New(NewSeeded(1)).Read(buf)
+	assert.Nil(t, err)
+	assert.Equal(t, 13, n)
+	assert.NotEqual(t, make([]byte, 13), buf)
+}
+
+func TestDefault(t *testing.T) {
+	seeded := New(NewSeeded(1))
+	prev := SetDefault(seeded)
+	assert.Equal(t, seeded, Default())
+	assert.Equal(t, seeded, SetDefault(prev))
+	assert.Equal(t, prev, Default())
+
+	SetDefault(nil)
+	defer SetDefault(prev)
+	assert.NotNil(t, Default())
+	assert.NotSame(t, prev, Default())
+}
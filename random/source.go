@@ -0,0 +1,84 @@
+// Package random 可替换来源的随机数工具
+//
+// NewSecure 基于 crypto/rand，适用于令牌、验证码等不可预测的场景；
+// NewSeeded 为固定种子的确定性来源，适用于测试数据与可复现的分桶。
+package random
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"io"
+	"math/bits"
+	"sync"
+)
+
+// Source 随机数来源，实现需可安全地并发使用
+type Source interface {
+	Uint64() uint64
+}
+
+// crypto/rand 来源，按块读取以减少系统调用
+type secureSource struct {
+	mu  sync.Mutex
+	buf [512]byte
+	pos int
+}
+
+// NewSecure 返回基于 crypto/rand 的来源，读取失败时 panic
+func NewSecure() Source {
+	s := &secureSource{}
+	s.pos = len(s.buf)
+	return s
+}
+
+func (s *secureSource) Uint64() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.pos+8 > len(s.buf) {
+		if _, err := io.ReadFull(crand.Reader, s.buf[:]); err != nil {
+			panic("random: crypto/rand read failed: " + err.Error())
+		}
+		s.pos = 0
+	}
+	v := binary.LittleEndian.Uint64(s.buf[s.pos:])
+	s.pos += 8
+	return v
+}
+
+// xoshiro256** 生成器
+type seededSource struct {
+	mu sync.Mutex
+	s  [4]uint64
+}
+
+// NewSeeded 返回固定种子的确定性来源(xoshiro256**)，相同种子产生相同序列
+func NewSeeded(seed uint64) Source {
+	src := &seededSource{}
+	for i := range src.s {
+		src.s[i] = splitmix64(&seed)
+	}
+	return src
+}
+
+func (src *seededSource) Uint64() uint64 {
+	src.mu.Lock()
+	defer src.mu.Unlock()
+	s := &src.s
+	result := bits.RotateLeft64(s[1]*5, 7) * 9
+	t := s[1] << 17
+	s[2] ^= s[0]
+	s[3] ^= s[1]
+	s[1] ^= s[2]
+	s[0] ^= s[3]
+	s[2] ^= t
+	s[3] = bits.RotateLeft64(s[3], 45)
+	return result
+}
+
+func splitmix64(x *uint64) uint64 {
+	*x += 0x9e3779b97f4a7c15
+	z := *x
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
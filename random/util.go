@@ -0,0 +1,194 @@
+package random
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Int64 返回 [min, max] 的无偏随机数，max < min 时 panic
+func (r *Rand) Int64(min, max int64) int64 {
+	if max < min {
+		panic("random: invalid range for Int64")
+	}
+	span := uint64(max-min) + 1
+	if span == 0 {
+		// [MinInt64, MaxInt64] 全范围
+		return int64(r.Uint64())
+	}
+	return min + int64(r.Uint64n(span))
+}
+
+// Int 返回 [min, max] 的无偏随机数，max < min 时 panic
+func (r *Rand) Int(min, max int) int {
+	return int(r.Int64(int64(min), int64(max)))
+}
+
+// Float64 返回 [0, 1) 的随机浮点数
+func (r *Rand) Float64() float64 {
+	return float64(r.Uint64()>>11) / (1 << 53)
+}
+
+// Float 返回 [min, max) 的随机浮点数
+func (r *Rand) Float(min, max float64) float64 {
+	return min + (max-min)*r.Float64()
+}
+
+// Bool 返回随机布尔值
+func (r *Rand) Bool() bool {
+	return r.Uint64()&1 == 1
+}
+
+func orDefault(r *Rand) *Rand {
+	if r == nil {
+		return Default()
+	}
+	return r
+}
+
+// Shuffle 原地随机打乱 s(Fisher-Yates)，r 为 nil 时使用 Default()
+func Shuffle[T any](r *Rand, s []T) {
+	r = orDefault(r)
+	for i := len(s) - 1; i > 0; i-- {
+		j := r.Intn(i + 1)
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// Sample 从 s 中不放回地随机选取 k 个元素，k 大于 len(s) 时返回全部元素的随机排列，不修改 s
+func Sample[T any](r *Rand, s []T, k int) []T {
+	r = orDefault(r)
+	if k > len(s) {
+		k = len(s)
+	}
+	if k <= 0 {
+		return []T{}
+	}
+
+	tmp := make([]T, len(s))
+	copy(tmp, s)
+	for i := 0; i < k; i++ {
+		j := i + r.Intn(len(tmp)-i)
+		tmp[i], tmp[j] = tmp[j], tmp[i]
+	}
+	return tmp[:k:k]
+}
+
+// Choice 从 s 中随机选取一个元素，s 为空时 panic
+func Choice[T any](r *Rand, s []T) T {
+	if len(s) == 0 {
+		panic("random: Choice from empty slice")
+	}
+	return s[orDefault(r).Intn(len(s))]
+}
+
+// WeightedChoice 按权重随机选取，使用别名方法(Vose)预处理，每次选取为 O(1)，可安全地并发使用
+type WeightedChoice[T any] struct {
+	items []T
+	prob  []float64
+	alias []int
+}
+
+// NewWeightedChoice 创建按权重选取的选择器，weights 需与 items 一一对应，权重为非负有限数且总和大于 0
+func NewWeightedChoice[T any](items []T, weights []float64) (*WeightedChoice[T], error) {
+	n := len(items)
+	if n == 0 || n != len(weights) {
+		return nil, fmt.Errorf("random: got %d items and %d weights", n, len(weights))
+	}
+
+	var total float64
+	for _, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return nil, fmt.Errorf("random: invalid weight %v", w)
+		}
+		total += w
+	}
+	if total <= 0 || math.IsInf(total, 0) {
+		return nil, errors.New("random: total weight must be positive and finite")
+	}
+
+	wc := &WeightedChoice[T]{
+		items: append([]T(nil), items...),
+		prob:  make([]float64, n),
+		alias: make([]int, n),
+	}
+	scaled := make([]float64, n)
+	var small, large []int
+	for i, w := range weights {
+		scaled[i] = w * float64(n) / total
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+
+	for len(small) > 0 && len(large) > 0 {
+		l, g := small[len(small)-1], large[len(large)-1]
+		small, large = small[:len(small)-1], large[:len(large)-1]
+		wc.prob[l] = scaled[l]
+		wc.alias[l] = g
+		scaled[g] += scaled[l] - 1
+		if scaled[g] < 1 {
+			small = append(small, g)
+		} else {
+			large = append(large, g)
+		}
+	}
+	// 剩余项因浮点误差接近 1
+	for _, i := range large {
+		wc.prob[i] = 1
+	}
+	for _, i := range small {
+		wc.prob[i] = 1
+	}
+	return wc, nil
+}
+
+// Pick 按权重随机选取一个元素，r 为 nil 时使用 Default()
+func (wc *WeightedChoice[T]) Pick(r *Rand) T {
+	r = orDefault(r)
+	i := r.Intn(len(wc.items))
+	if r.Float64() < wc.prob[i] {
+		return wc.items[i]
+	}
+	return wc.items[wc.alias[i]]
+}
+
+// Reservoir 蓄水池抽样，从未知长度的数据流中等概率保留 k 个元素，不可并发使用
+type Reservoir[T any] struct {
+	r     *Rand
+	k     int
+	count uint64
+	items []T
+}
+
+// NewReservoir 创建容量为 k 的蓄水池，r 为 nil 时使用 Default()
+func NewReservoir[T any](r *Rand, k int) *Reservoir[T] {
+	if k < 0 {
+		k = 0
+	}
+	return &Reservoir[T]{r: orDefault(r), k: k, items: make([]T, 0, k)}
+}
+
+// Add 加入一个元素
+func (rs *Reservoir[T]) Add(item T) {
+	rs.count++
+	if len(rs.items) < rs.k {
+		rs.items = append(rs.items, item)
+		return
+	}
+	if j := rs.r.Uint64n(rs.count); j < uint64(rs.k) {
+		rs.items[j] = item
+	}
+}
+
+// Items 返回当前保留的元素
+func (rs *Reservoir[T]) Items() []T {
+	return append([]T(nil), rs.items...)
+}
+
+// Count 返回已加入的元素个数
+func (rs *Reservoir[T]) Count() uint64 {
+	return rs.count
+}
@@ -0,0 +1,125 @@
+package random
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntFloat(t *testing.T) {
+	r := New(NewSeeded(1))
+	seen := map[int]bool{}
+	for i := 0; i < 1000; i++ {
+		v := r.Int(-2, 2)
+		assert.True(t, v >= -2 && v <= 2)
+		seen[v] = true
+	}
+	assert.Equal(t, 5, len(seen))
+	assert.Equal(t, 7, r.Int(7, 7))
+	r.Int64(math.MinInt64, math.MaxInt64)
+	assert.Panics(t, func() { r.Int(2, 1) })
+
+	for i := 0; i < 1000; i++ {
+		f := r.Float64()
+		assert.True(t, f >= 0 && f < 1)
+		f = r.Float(-1.5, 1.5)
+		assert.True(t, f >= -1.5 && f < 1.5)
+	}
+
+	trues := 0
+	for i := 0; i < 10000; i++ {
+		if r.Bool() {
+			trues++
+		}
+	}
+	assert.InDelta(t, 5000, trues, 300)
+}
+
+func TestShuffleSample(t *testing.T) {
+	r := New(NewSeeded(1))
+	s := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	Shuffle(r, s)
+	assert.NotEqual(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, s)
+	sorted := append([]int(nil), s...)
+	sort.Ints(sorted)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, sorted)
+
+	// 相同种子结果相同
+	a, b := []string{"a", "b", "c", "d"}, []string{"a", "b", "c", "d"}
+	Shuffle(New(NewSeeded(9)), a)
+	Shuffle(New(NewSeeded(9)), b)
+	assert.Equal(t, a, b)
+
+	orig := []int{1, 2, 3, 4, 5}
+	sample := Sample(r, orig, 3)
+	assert.Len(t, sample, 3)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, orig)
+	seen := map[int]bool{}
+	for _, v := range sample {
+		assert.False(t, seen[v])
+		seen[v] = true
+	}
+	assert.Len(t, Sample(r, orig, 10), 5)
+	assert.Empty(t, Sample(r, orig, 0))
+	assert.Empty(t, Sample[int](nil, nil, 3))
+
+	assert.Contains(t, orig, Choice(r, orig))
+	assert.Panics(t, func() { Choice(r, []int{}) })
+}
+
+func TestWeightedChoice(t *testing.T) {
+	wc, err := NewWeightedChoice([]string{"a", "b", "c", "d"}, []float64{1, 2, 7, 0})
+	assert.Nil(t, err)
+
+	r := New(NewSeeded(1))
+	counts := map[string]int{}
+	for i := 0; i < 100000; i++ {
+		counts[wc.Pick(r)]++
+	}
+	assert.InDelta(t, 10000, counts["a"], 600)
+	assert.InDelta(t, 20000, counts["b"], 800)
+	assert.InDelta(t, 70000, counts["c"], 1000)
+	assert.Equal(t, 0, counts["d"])
+
+	one, err := NewWeightedChoice([]int{42}, []float64{0.5})
+	assert.Nil(t, err)
+	assert.Equal(t, 42, one.Pick(nil))
+
+	for _, weights := range [][]float64{{}, {1}, {0, 0}, {-1, 2}, {math.NaN(), 1}, {math.Inf(1), 1}} {
+		items := []int{1, 2}
+		if len(weights) == 0 {
+			items = nil
+		}
+		_, err := NewWeightedChoice(items, weights)
+		assert.NotNil(t, err, weights)
+	}
+}
+
+func TestReservoir(t *testing.T) {
+	rs := NewReservoir[int](New(NewSeeded(1)), 3)
+	rs.Add(1)
+	rs.Add(2)
+	assert.Equal(t, []int{1, 2}, rs.Items())
+
+	// 每个元素被保留的概率均为 k/n
+	counts := make([]int, 10)
+	for round := 0; round < 10000; round++ {
+		rs := NewReservoir[int](New(NewSeeded(uint64(round))), 3)
+		for i := 0; i < 10; i++ {
+			rs.Add(i)
+		}
+		assert.Equal(t, uint64(10), rs.Count())
+		for _, v := range rs.Items() {
+			counts[v]++
+		}
+	}
+	for _, c := range counts {
+		assert.InDelta(t, 3000, c, 250)
+	}
+
+	empty := NewReservoir[string](nil, 0)
+	empty.Add("a")
+	assert.Empty(t, empty.Items())
+}
@@ -6,6 +6,9 @@ import (
 	"math"
 	"reflect"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
 // If 根据条件判断返回不同的值。
@@ -202,3 +205,115 @@ func (at AnyType) Int64Array(arr []any, dstArr *[]int64) error {
 
 	return nil
 }
+
+// At.Time 对数值类型时间戳返回的 layout
+const (
+	LayoutUnix      = "unix"
+	LayoutUnixMilli = "unixmilli"
+	LayoutUnixMicro = "unixmicro"
+	LayoutUnixNano  = "unixnano"
+)
+
+var (
+	timeLayoutsMu sync.RWMutex
+	timeLayouts   = []string{
+		Ymd, YmdHis, time.RFC3339,
+		"2006年01月02日", "2006年01月02日 15:04:05", "2006年1月2日", "2006年1月2日 15:04:05",
+	}
+)
+
+// SetTimeLayouts 设置 At.Time 解析字符串时依次尝试的 layout 列表
+func (at AnyType) SetTimeLayouts(layouts ...string) {
+	timeLayoutsMu.Lock()
+	defer timeLayoutsMu.Unlock()
+	timeLayouts = append([]string(nil), layouts...)
+}
+
+// TimeLayouts 返回 At.Time 解析字符串时依次尝试的 layout 列表
+func (at AnyType) TimeLayouts() []string {
+	timeLayoutsMu.RLock()
+	defer timeLayoutsMu.RUnlock()
+	return append([]string(nil), timeLayouts...)
+}
+
+// Time 将 any 类型的值转换为 time.Time 类型的值，并返回匹配的 layout。
+// 支持以下类型：time.Time, *time.Time, 整数与浮点数时间戳, string（数字时间戳或符合 TimeLayouts 中任一 layout 的时间字符串）。
+// 数字时间戳按数值大小自动识别秒、毫秒、微秒、纳秒，layout 分别为 LayoutUnix, LayoutUnixMilli, LayoutUnixMicro, LayoutUnixNano。
+// loc 为 nil 时使用 time.Local，字符串中不含时区时按 loc 解析。
+// 注：纯数字字符串总是作为时间戳处理，20060102 这样的紧凑格式请使用 Tt.Parse
+func (at AnyType) Time(fromVal any, loc *time.Location) (t time.Time, layout string, err error) {
+	if loc == nil {
+		loc = time.Local
+	}
+
+	switch v := fromVal.(type) {
+	case time.Time:
+		return v.In(loc), "", nil
+	case *time.Time:
+		if v == nil {
+			return t, "", errors.New("gu.At.Time() Error: nil time")
+		}
+		return v.In(loc), "", nil
+	case float32, float64:
+		f := reflect.ValueOf(v).Float()
+		if math.IsNaN(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return t, "", fmt.Errorf("gu.At.Time() Error: invalid timestamp %v", f)
+		}
+		n, frac := math.Modf(f)
+		t, layout = unixTime(int64(n))
+		// 小数部分按识别出的单位换算
+		t = t.Add(time.Duration(frac * float64(unixUnits[layout])))
+		return t.In(loc), layout, nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr:
+		var n int64
+		if err = at.Int64(v, &n); err != nil {
+			return t, "", fmt.Errorf("gu.At.Time() Error: %s", err.Error())
+		}
+		t, layout = unixTime(n)
+		return t.In(loc), layout, nil
+	}
+
+	rv := reflect.ValueOf(fromVal)
+	if rv.Kind() != reflect.String {
+		return t, "", errors.New("gu.At.Time() Error: unsupported type")
+	}
+
+	str := strings.TrimSpace(rv.String())
+	if n, e := strconv.ParseInt(str, 10, 64); e == nil {
+		t, layout = unixTime(n)
+		return t.In(loc), layout, nil
+	}
+	for _, l := range at.TimeLayouts() {
+		if t, err = time.ParseInLocation(l, str, loc); err == nil {
+			return t, l, nil
+		}
+	}
+
+	return time.Time{}, "", fmt.Errorf("gu.At.Time() Error: no layout matched %q", str)
+}
+
+// 时间戳 layout 对应的单位
+var unixUnits = map[string]time.Duration{
+	LayoutUnix:      time.Second,
+	LayoutUnixMilli: time.Millisecond,
+	LayoutUnixMicro: time.Microsecond,
+	LayoutUnixNano:  time.Nanosecond,
+}
+
+// 按数值大小识别时间戳单位
+func unixTime(n int64) (time.Time, string) {
+	abs := n
+	if abs < 0 {
+		abs = -abs
+	}
+
+	switch {
+	case abs < 1e11:
+		return time.Unix(n, 0), LayoutUnix
+	case abs < 1e14:
+		return time.UnixMilli(n), LayoutUnixMilli
+	case abs < 1e17:
+		return time.UnixMicro(n), LayoutUnixMicro
+	}
+	return time.Unix(0, n), LayoutUnixNano
+}
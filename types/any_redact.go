@@ -0,0 +1,136 @@
+package types
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// Redact 递归的最大深度，防止循环引用，超出的部分置为零值
+const redactMaxDepth = 32
+
+// 返回按 mask 标签遮盖后的副本，不修改原值，适用于记录日志前脱敏
+//
+// v 可为结构体、结构体指针、切片或 map，递归处理嵌套的结构体、指针、切片、数组与 map，
+// 嵌入的结构体(包括非导出类型)同样处理其导出字段。
+// 标签作用于 string、*string、[]string 类型的导出字段，取值见 St.Mask* 方法:
+//
+//	Phone string `mask:"phone"`    // 138****5678
+//	Email string `mask:"email"`
+//	ID    string `mask:"idcard"`
+//	Card  string `mask:"bankcard"`
+//	Name  string `mask:"name"`
+//	Token string `mask:"all"`      // 全部遮盖，未知标签同样全部遮盖
+//	Code  string `mask:"2,2"`      // 保留左侧 2 个与右侧 2 个字符
+func (at AnyType) Redact(v any) any {
+	if v == nil {
+		return nil
+	}
+	return redactValue(reflect.ValueOf(v), 0).Interface()
+}
+
+func redactValue(v reflect.Value, depth int) reflect.Value {
+	if depth > redactMaxDepth {
+		// 无法确认是否含有敏感信息，置为零值
+		return reflect.Zero(v.Type())
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		p := reflect.New(v.Type().Elem())
+		p.Elem().Set(redactValue(v.Elem(), depth+1))
+		return p
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(redactValue(v.Elem(), depth+1))
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		typ := v.Type()
+		for i := 0; i < typ.NumField(); i++ {
+			f := typ.Field(i)
+			if !f.IsExported() {
+				// 与 encoding/json 一致，非导出的嵌入结构体处理其导出字段
+				if f.Anonymous && isStructOrPtr(f.Type) {
+					fv := c.Field(i)
+					fv = reflect.NewAt(fv.Type(), unsafe.Pointer(fv.UnsafeAddr())).Elem()
+					fv.Set(redactValue(fv, depth+1))
+				}
+				continue
+			}
+			if tag, ok := f.Tag.Lookup("mask"); ok && tag != "-" {
+				c.Field(i).Set(maskValue(c.Field(i), tag, depth+1))
+			} else {
+				c.Field(i).Set(redactValue(c.Field(i), depth+1))
+			}
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(redactValue(v.Index(i), depth+1))
+		}
+		return c
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(redactValue(v.Index(i), depth+1))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), redactValue(iter.Value(), depth+1))
+		}
+		return c
+	}
+	return v
+}
+
+func isStructOrPtr(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+// 按标签遮盖 string、*string 与 []string，其他类型按普通字段递归处理
+func maskValue(v reflect.Value, tag string, depth int) reflect.Value {
+	var st StrType
+	switch {
+	case v.Kind() == reflect.String:
+		c := reflect.New(v.Type()).Elem()
+		c.SetString(st.maskByTag(v.String(), tag))
+		return c
+	case v.Kind() == reflect.Pointer && v.Type().Elem().Kind() == reflect.String:
+		if v.IsNil() {
+			return v
+		}
+		p := reflect.New(v.Type().Elem())
+		p.Elem().SetString(st.maskByTag(v.Elem().String(), tag))
+		return p
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).SetString(st.maskByTag(v.Index(i).String(), tag))
+		}
+		return c
+	}
+	return redactValue(v, depth)
+}
@@ -0,0 +1,136 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type redactAddress struct {
+	Detail string `mask:"3,0"`
+	City   string
+}
+
+type redactUser struct {
+	Name      string   `mask:"name"`
+	Phone     string   `mask:"phone"`
+	Email     *string  `mask:"email"`
+	IDCard    string   `mask:"idcard"`
+	Cards     []string `mask:"bankcard"`
+	Token     string   `mask:"all"`
+	Note      string   `mask:"-"`
+	Age       int      `mask:"phone"`
+	Address   *redactAddress
+	Backups   []redactAddress
+	Extra     map[string]any
+	CreatedAt time.Time
+	password  string
+}
+
+func TestRedact(t *testing.T) {
+	email := "zhangsan@example.com"
+	created := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	u := &redactUser{
+		Name:      "张三",
+		Phone:     "13812345678",
+		Email:     &email,
+		IDCard:    "11010119900307123X",
+		Cards:     []string{"6222021234567890"},
+		Token:     "secret",
+		Note:      "keep",
+		Age:       30,
+		Address:   &redactAddress{Detail: "浦东新区世纪大道", City: "上海"},
+		Backups:   []redactAddress{{Detail: "朝阳区建国路", City: "北京"}},
+		Extra:     map[string]any{"contact": redactAddress{Detail: "abcdef"}},
+		CreatedAt: created,
+		password:  "pwd",
+	}
+
+	r, ok := at.Redact(u).(*redactUser)
+	assert.True(t, ok)
+	assert.Equal(t, "张*", r.Name)
+	assert.Equal(t, "138****5678", r.Phone)
+	assert.Equal(t, "z******n@example.com", *r.Email)
+	assert.Equal(t, "110101********123X", r.IDCard)
+	assert.Equal(t, []string{"622202******7890"}, r.Cards)
+	assert.Equal(t, "******", r.Token)
+	assert.Equal(t, "keep", r.Note)
+	assert.Equal(t, 30, r.Age)
+	assert.Equal(t, "浦东新*****", r.Address.Detail)
+	assert.Equal(t, "上海", r.Address.City)
+	assert.Equal(t, "朝阳区***", r.Backups[0].Detail)
+	assert.Equal(t, "abc***", r.Extra["contact"].(redactAddress).Detail)
+	assert.True(t, r.CreatedAt.Equal(created))
+	assert.Equal(t, "pwd", r.password)
+
+	// 原值不变
+	assert.Equal(t, "13812345678", u.Phone)
+	assert.Equal(t, "zhangsan@example.com", email)
+	assert.Equal(t, "6222021234567890", u.Cards[0])
+	assert.Equal(t, "浦东新区世纪大道", u.Address.Detail)
+	assert.Equal(t, "朝阳区建国路", u.Backups[0].Detail)
+
+	// 值类型、切片与 nil
+	v := at.Redact(redactUser{Phone: "13812345678"}).(redactUser)
+	assert.Equal(t, "138****5678", v.Phone)
+	assert.Nil(t, v.Email)
+	list := at.Redact([]redactUser{{Name: "欧阳锋"}}).([]redactUser)
+	assert.Equal(t, "欧*锋", list[0].Name)
+	assert.Nil(t, at.Redact(nil))
+	assert.Equal(t, "plain", at.Redact("plain"))
+}
+
+type redactNode struct {
+	Phone string `mask:"phone"`
+	Next  *redactNode
+}
+
+func TestRedactCycle(t *testing.T) {
+	n := &redactNode{Phone: "13812345678"}
+	n.Next = n
+
+	got := at.Redact(n).(*redactNode)
+	assert.Equal(t, "13812345678", n.Phone)
+	depth := 0
+	for p := got; p != nil; p = p.Next {
+		// 超出深度的部分为零值，不会泄露原值
+		assert.Contains(t, []string{"138****5678", ""}, p.Phone)
+		assert.NotSame(t, n, p)
+		depth++
+	}
+	assert.True(t, depth <= redactMaxDepth)
+	assert.Equal(t, "138****5678", got.Next.Phone)
+}
+
+type redactContact struct {
+	Phone string `mask:"phone"`
+	note  string
+}
+
+type redactEmbedded struct {
+	redactContact
+	*redactAddress
+	Name string `mask:"name"`
+}
+
+func TestRedactEmbedded(t *testing.T) {
+	src := redactEmbedded{
+		redactContact: redactContact{Phone: "13812345678", note: "n"},
+		redactAddress: &redactAddress{Detail: "北京市朝阳区", City: "北京"},
+		Name:          "张三",
+	}
+	got := at.Redact(src).(redactEmbedded)
+	assert.Equal(t, "138****5678", got.Phone)
+	assert.Equal(t, "n", got.note)
+	assert.Equal(t, "北京市***", got.Detail)
+	assert.Equal(t, "北京", got.City)
+	assert.Equal(t, "张*", got.Name)
+
+	// 不修改原值
+	assert.Equal(t, "13812345678", src.Phone)
+	assert.Equal(t, "北京市朝阳区", src.Detail)
+
+	ptr := at.Redact(&src).(*redactEmbedded)
+	assert.Equal(t, "138****5678", ptr.Phone)
+}
@@ -1,10 +1,12 @@
 package types
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -236,3 +238,72 @@ func TestAnyUtils(t *testing.T) {
 	err = at.Int64Array(fromAny3, &int64Arr2)
 	assert.Equal(t, errors.New("gu.At.Int64() Error: unsupported type"), err)
 }
+
+func TestAnyTime(t *testing.T) {
+	shanghai := time.FixedZone("CST", 8*3600)
+	sec := time.Date(2024, 3, 5, 7, 8, 9, 0, shanghai)
+
+	cases := []struct {
+		from   any
+		expect time.Time
+		layout string
+	}{
+		{sec.Unix(), sec, LayoutUnix},
+		{int32(sec.Unix()), sec, LayoutUnix},
+		{uint64(sec.Unix()), sec, LayoutUnix},
+		{sec.UnixMilli() + 123, sec.Add(123 * time.Millisecond), LayoutUnixMilli},
+		{sec.UnixMicro() + 5, sec.Add(5 * time.Microsecond), LayoutUnixMicro},
+		{sec.UnixNano() + 7, sec.Add(7), LayoutUnixNano},
+		{float64(sec.Unix()) + 0.5, sec.Add(500 * time.Millisecond), LayoutUnix},
+		{float64(sec.UnixMilli()), sec, LayoutUnixMilli},
+		{float64(sec.UnixMilli()) + 0.5, sec.Add(500 * time.Microsecond), LayoutUnixMilli},
+		{float64(sec.UnixMicro()) + 0.25, sec.Add(250 * time.Nanosecond), LayoutUnixMicro},
+		{float32(1e9), time.Unix(1e9, 0), LayoutUnix},
+		{fmt.Sprint(sec.Unix()), sec, LayoutUnix},
+		{fmt.Sprint(sec.UnixMilli()), sec, LayoutUnixMilli},
+		{json.Number(fmt.Sprint(sec.Unix())), sec, LayoutUnix},
+		{"2024-03-05", time.Date(2024, 3, 5, 0, 0, 0, 0, shanghai), Ymd},
+		{"2024-03-05 07:08:09", sec, YmdHis},
+		{"2024-03-05T07:08:09+08:00", sec, time.RFC3339},
+		{"2024-03-04T23:08:09.5Z", sec.Add(500 * time.Millisecond), time.RFC3339},
+		{"2024-03-05 07:08:09.250", sec.Add(250 * time.Millisecond), YmdHis},
+		{"2024年03月05日", time.Date(2024, 3, 5, 0, 0, 0, 0, shanghai), "2006年01月02日"},
+		{" 2024年3月5日 07:08:09 ", sec, "2006年1月2日 15:04:05"},
+		{sec, sec, ""},
+		{&sec, sec, ""},
+	}
+
+	for _, c := range cases {
+		ti, layout, err := at.Time(c.from, shanghai)
+		assert.Nil(t, err, c.from)
+		assert.True(t, c.expect.Equal(ti), "%v: %v", c.from, ti)
+		assert.Equal(t, c.layout, layout, c.from)
+	}
+
+	ti, _, err := at.Time(int64(0), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Local, ti.Location())
+
+	var nilTime *time.Time
+	for _, bad := range []any{"2024/03/05", "", true, nil, nilTime, uint64(math.MaxUint64),
+		math.NaN(), math.Inf(1), math.Inf(-1), 1e19, -1e19, float32(math.Inf(1))} {
+		_, _, err = at.Time(bad, time.UTC)
+		assert.NotNil(t, err, bad)
+	}
+}
+
+func TestTimeLayouts(t *testing.T) {
+	prev := at.TimeLayouts()
+	defer at.SetTimeLayouts(prev...)
+
+	at.SetTimeLayouts("2006/01/02")
+	assert.Equal(t, []string{"2006/01/02"}, at.TimeLayouts())
+
+	ti, layout, err := at.Time("2024/03/05", time.UTC)
+	assert.Nil(t, err)
+	assert.Equal(t, "2006/01/02", layout)
+	assert.Equal(t, time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), ti)
+
+	_, _, err = at.Time("2024-03-05", time.UTC)
+	assert.NotNil(t, err)
+}
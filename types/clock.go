@@ -0,0 +1,50 @@
+package types
+
+import (
+	"sync"
+	"time"
+
+	"github.com/arnoluo/gu/clock"
+)
+
+var (
+	clockMu   sync.RWMutex
+	timeClock clock.Clock = clock.Real
+)
+
+// 设置 Tt.Now / Tt.Since / Tt.Today 使用的时钟，传入 nil 时恢复为系统时钟
+//
+// 返回之前的时钟，便于测试结束后还原
+func (tt TimeType) SetClock(c clock.Clock) clock.Clock {
+	if c == nil {
+		c = clock.Real
+	}
+
+	clockMu.Lock()
+	defer clockMu.Unlock()
+	prev := timeClock
+	timeClock = c
+	return prev
+}
+
+// 返回当前使用的时钟
+func (tt TimeType) Clock() clock.Clock {
+	clockMu.RLock()
+	defer clockMu.RUnlock()
+	return timeClock
+}
+
+// 返回时钟的当前时间
+func (tt TimeType) Now() time.Time {
+	return tt.Clock().Now()
+}
+
+// 返回 t 到当前时间的时长
+func (tt TimeType) Since(t time.Time) time.Duration {
+	return tt.Clock().Since(t)
+}
+
+// 返回今天零点(本地时区)
+func (tt TimeType) Today() time.Time {
+	return tt.StartOfDay(tt.Now().Local())
+}
@@ -0,0 +1,26 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/arnoluo/gu/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClock(t *testing.T) {
+	now := time.Date(2024, 2, 29, 13, 4, 5, 0, time.Local)
+	fc := clock.NewFake(now)
+	prev := tt.SetClock(fc)
+	defer tt.SetClock(prev)
+
+	assert.Equal(t, clock.Real, prev)
+	assert.Equal(t, now, tt.Now())
+	fc.Advance(time.Hour)
+	assert.Equal(t, time.Hour, tt.Since(now))
+	assert.Equal(t, time.Date(2024, 2, 29, 0, 0, 0, 0, time.Local), tt.Today())
+	assert.Equal(t, "1 hour ago", tt.Humanize(now, tt.Now()))
+
+	assert.Equal(t, fc, tt.SetClock(nil))
+	assert.Equal(t, clock.Real, tt.Clock())
+}
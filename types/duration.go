@@ -0,0 +1,370 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	Day  time.Duration = 24 * time.Hour
+	Week time.Duration = 7 * Day
+)
+
+// 时长单位，按长度降序排列以便最长匹配
+var durationUnits = []struct {
+	name string
+	unit time.Duration
+}{
+	{"minutes", time.Minute}, {"seconds", time.Second}, {"个小时", time.Hour},
+	{"minute", time.Minute}, {"second", time.Second},
+	{"hours", time.Hour}, {"weeks", Week},
+	{"hour", time.Hour}, {"week", Week}, {"days", Day}, {"mins", time.Minute}, {"secs", time.Second},
+	{"day", Day}, {"min", time.Minute}, {"sec", time.Second}, {"hrs", time.Hour},
+	{"hr", time.Hour}, {"ns", time.Nanosecond}, {"us", time.Microsecond}, {"µs", time.Microsecond},
+	{"μs", time.Microsecond}, {"ms", time.Millisecond},
+	{"纳秒", time.Nanosecond}, {"微秒", time.Microsecond}, {"毫秒", time.Millisecond},
+	{"秒钟", time.Second}, {"分钟", time.Minute}, {"小时", time.Hour}, {"钟头", time.Hour}, {"星期", Week},
+	{"s", time.Second}, {"m", time.Minute}, {"h", time.Hour}, {"d", Day}, {"w", Week},
+	{"秒", time.Second}, {"分", time.Minute}, {"时", time.Hour}, {"天", Day}, {"日", Day}, {"周", Week},
+}
+
+// 解析时长，在 time.ParseDuration 基础上支持天(d)、周(w)、单词单位与中文单位，数字与单位间可有空格
+//
+// 如 "1d12h", "2w", "1.5h", "1 day 2 hours", "1天2小时30分钟", "-3m"
+func (tt TimeType) ParseDuration(str string) (time.Duration, error) {
+	s := strings.TrimSpace(str)
+	if s == "" {
+		return 0, fmt.Errorf("gu.Tt.ParseDuration() Error: invalid duration %q", str)
+	}
+
+	neg := false
+	if s[0] == '-' || s[0] == '+' {
+		neg = s[0] == '-'
+		s = strings.TrimSpace(s[1:])
+	}
+	if s == "" {
+		return 0, fmt.Errorf("gu.Tt.ParseDuration() Error: invalid duration %q", str)
+	}
+	if s == "0" {
+		return 0, nil
+	}
+
+	var total float64
+	for s != "" {
+		// 数字部分
+		i := 0
+		for i < len(s) && (s[i] == '.' || (s[i] >= '0' && s[i] <= '9')) {
+			i++
+		}
+		if i == 0 {
+			return 0, fmt.Errorf("gu.Tt.ParseDuration() Error: invalid duration %q", str)
+		}
+		n, err := strconv.ParseFloat(s[:i], 64)
+		if err != nil {
+			return 0, fmt.Errorf("gu.Tt.ParseDuration() Error: invalid duration %q", str)
+		}
+		s = strings.TrimLeftFunc(s[i:], unicode.IsSpace)
+
+		// 单位部分
+		unit, size := matchDurationUnit(s)
+		if size == 0 {
+			return 0, fmt.Errorf("gu.Tt.ParseDuration() Error: missing or unknown unit in duration %q", str)
+		}
+		s = strings.TrimLeftFunc(s[size:], func(r rune) bool { return unicode.IsSpace(r) || r == ',' })
+
+		total += n * float64(unit)
+		// float64(math.MaxInt64) 即 2^63，需使用 >=
+		if total >= 1<<63 {
+			return 0, fmt.Errorf("gu.Tt.ParseDuration() Error: duration %q overflows", str)
+		}
+	}
+
+	if neg {
+		total = -total
+	}
+	return time.Duration(math.Round(total)), nil
+}
+
+func matchDurationUnit(s string) (time.Duration, int) {
+	lower := strings.ToLower(s)
+	for _, u := range durationUnits {
+		if !strings.HasPrefix(lower, u.name) {
+			continue
+		}
+		// 英文单位需完整匹配单词，避免 "1mo" 被识别为 1m
+		if next, _ := utf8.DecodeRuneInString(lower[len(u.name):]); next < utf8.RuneSelf && unicode.IsLetter(next) {
+			continue
+		}
+		return u.unit, len(u.name)
+	}
+	return 0, 0
+}
+
+// 格式化时长，如 "1d 2h 3m"，precision 为最多显示的单位个数(<=0 时显示全部)，其余部分截断
+//
+// 小于 1s 的时长使用 ms / µs / ns
+func (tt TimeType) FormatDuration(d time.Duration, precision int) string {
+	if d == 0 {
+		return "0s"
+	}
+
+	var sb strings.Builder
+	u := uint64(d)
+	if d < 0 {
+		sb.WriteByte('-')
+		u = -u
+	}
+
+	units := []struct {
+		name string
+		unit uint64
+	}{
+		{"d", uint64(Day)}, {"h", uint64(time.Hour)}, {"m", uint64(time.Minute)}, {"s", uint64(time.Second)},
+		{"ms", uint64(time.Millisecond)}, {"µs", uint64(time.Microsecond)}, {"ns", 1},
+	}
+
+	subSecond := u < uint64(time.Second)
+	count := 0
+	for _, unit := range units {
+		if precision > 0 && count >= precision {
+			break
+		}
+		// 不小于 1s 时不显示毫秒以下单位
+		if unit.unit < uint64(time.Second) && !subSecond {
+			break
+		}
+		n := u / unit.unit
+		if n == 0 {
+			continue
+		}
+		u -= n * unit.unit
+		if count > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(strconv.FormatUint(n, 10))
+		sb.WriteString(unit.name)
+		count++
+	}
+
+	return sb.String()
+}
+
+// Period 日历时长，年月日按日历计算，Time 为精确时长部分
+type Period struct {
+	Years  int
+	Months int
+	Days   int
+	Time   time.Duration
+}
+
+// AddTo 将日历时长加到 t 上，年月按 Tt.AddMonths 规则取月末，天按自然日计算
+func (p Period) AddTo(t time.Time) time.Time {
+	var tt TimeType
+	t = tt.AddMonths(t, p.Years*12+p.Months)
+	return t.AddDate(0, 0, p.Days).Add(p.Time)
+}
+
+// IsZero 判断是否为零时长
+func (p Period) IsZero() bool {
+	return p == Period{}
+}
+
+// String 返回 ISO 8601 时长字符串，如 P1Y2M3DT4H5M6S
+func (p Period) String() string {
+	if p.IsZero() {
+		return "PT0S"
+	}
+
+	var sb strings.Builder
+	// 各部分均不大于 0 时使用前置负号
+	if p.Years <= 0 && p.Months <= 0 && p.Days <= 0 && p.Time <= 0 {
+		sb.WriteByte('-')
+		p = Period{-p.Years, -p.Months, -p.Days, -p.Time}
+	}
+	sb.WriteByte('P')
+	for _, part := range []struct {
+		n    int
+		unit byte
+	}{{p.Years, 'Y'}, {p.Months, 'M'}, {p.Days, 'D'}} {
+		if part.n != 0 {
+			sb.WriteString(strconv.Itoa(part.n))
+			sb.WriteByte(part.unit)
+		}
+	}
+	if p.Time != 0 {
+		sb.WriteByte('T')
+		writeISOTime(&sb, p.Time)
+	}
+	return sb.String()
+}
+
+func writeISOTime(sb *strings.Builder, d time.Duration) {
+	if d < 0 {
+		sb.WriteByte('-')
+		d = -d
+	}
+	h := d / time.Hour
+	d -= h * time.Hour
+	m := d / time.Minute
+	d -= m * time.Minute
+
+	if h > 0 {
+		sb.WriteString(strconv.FormatInt(int64(h), 10) + "H")
+	}
+	if m > 0 {
+		sb.WriteString(strconv.FormatInt(int64(m), 10) + "M")
+	}
+	if d > 0 {
+		sec := strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+		sb.WriteString(sec + "S")
+	}
+}
+
+// 解析 ISO 8601 时长，如 P1Y2M3DT4H5M6.5S, P2W, -P1D
+func (tt TimeType) ParsePeriod(str string) (Period, error) {
+	var p Period
+	invalid := fmt.Errorf("gu.Tt.ParsePeriod() Error: invalid ISO 8601 duration %q", str)
+	overflow := fmt.Errorf("gu.Tt.ParsePeriod() Error: duration %q overflows", str)
+
+	s := strings.ToUpper(strings.TrimSpace(str))
+	neg := false
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	if !strings.HasPrefix(s, "P") {
+		return p, invalid
+	}
+	s = s[1:]
+
+	inTime := false
+	seen := false
+	for s != "" {
+		if s[0] == 'T' {
+			if inTime || len(s) == 1 {
+				return p, invalid
+			}
+			inTime = true
+			s = s[1:]
+			continue
+		}
+
+		i := 0
+		for i < len(s) && (s[i] == '.' || s[i] == ',' || (s[i] >= '0' && s[i] <= '9')) {
+			i++
+		}
+		if i == 0 || i == len(s) {
+			return p, invalid
+		}
+		num := strings.Replace(s[:i], ",", ".", 1)
+		unit := s[i]
+		s = s[i+1:]
+		seen = true
+
+		if inTime {
+			f, err := strconv.ParseFloat(num, 64)
+			if err != nil {
+				return p, invalid
+			}
+			var size time.Duration
+			switch unit {
+			case 'H':
+				size = time.Hour
+			case 'M':
+				size = time.Minute
+			case 'S':
+				size = time.Second
+			default:
+				return p, invalid
+			}
+			v := math.Round(f * float64(size))
+			if v >= 1<<63 || p.Time+time.Duration(v) < p.Time {
+				return p, overflow
+			}
+			p.Time += time.Duration(v)
+			continue
+		}
+
+		n, err := strconv.Atoi(num)
+		if err != nil {
+			return p, invalid
+		}
+		var field *int
+		switch unit {
+		case 'Y':
+			field = &p.Years
+		case 'M':
+			field = &p.Months
+		case 'W':
+			if n > math.MaxInt/7 {
+				return p, overflow
+			}
+			field, n = &p.Days, n*7
+		case 'D':
+			field = &p.Days
+		default:
+			return p, invalid
+		}
+		if *field > math.MaxInt-n {
+			return p, overflow
+		}
+		*field += n
+	}
+
+	if !seen {
+		return p, invalid
+	}
+	if neg {
+		p = Period{-p.Years, -p.Months, -p.Days, -p.Time}
+	}
+	return p, nil
+}
+
+// 解析 ISO 8601 时长为 time.Duration，天按 24 小时计算，包含年或月时返回错误(请使用 ParsePeriod)
+func (tt TimeType) ParseISODuration(str string) (time.Duration, error) {
+	p, err := tt.ParsePeriod(str)
+	if err != nil {
+		return 0, err
+	}
+	if p.Years != 0 || p.Months != 0 {
+		return 0, errors.New("gu.Tt.ParseISODuration() Error: years and months are not fixed durations, use ParsePeriod")
+	}
+	if int64(p.Days) > math.MaxInt64/int64(Day) || int64(p.Days) < math.MinInt64/int64(Day) {
+		return 0, fmt.Errorf("gu.Tt.ParseISODuration() Error: duration %q overflows", str)
+	}
+	days := time.Duration(p.Days) * Day
+	d := days + p.Time
+	if (p.Time > 0 && d < days) || (p.Time < 0 && d > days) {
+		return 0, fmt.Errorf("gu.Tt.ParseISODuration() Error: duration %q overflows", str)
+	}
+	return d, nil
+}
+
+// 格式化为 ISO 8601 时长，天按 24 小时计算，如 P1DT2H
+func (tt TimeType) ISODuration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+
+	var sb strings.Builder
+	if d < 0 {
+		sb.WriteByte('-')
+		d = -d
+	}
+	sb.WriteByte('P')
+	if days := d / Day; days > 0 {
+		sb.WriteString(strconv.FormatInt(int64(days), 10) + "D")
+		d -= days * Day
+	}
+	if d > 0 {
+		sb.WriteByte('T')
+		writeISOTime(&sb, d)
+	}
+	return sb.String()
+}
@@ -0,0 +1,133 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDuration(t *testing.T) {
+	cases := map[string]time.Duration{
+		"0":               0,
+		"1d":              Day,
+		"2w":              2 * Week,
+		"1d12h":           36 * time.Hour,
+		"1.5h":            90 * time.Minute,
+		"-3m":             -3 * time.Minute,
+		"+1h30m":          90 * time.Minute,
+		"1h 30m 15s":      time.Hour + 30*time.Minute + 15*time.Second,
+		"300ms":           300 * time.Millisecond,
+		"10us":            10 * time.Microsecond,
+		"10µs":            10 * time.Microsecond,
+		"5ns":             5,
+		"1 day 2 hours":   26 * time.Hour,
+		"2 Weeks, 3 Days": 17 * Day,
+		"45 mins 10 secs": 45*time.Minute + 10*time.Second,
+		"1 minute":        time.Minute,
+		"2hrs":            2 * time.Hour,
+		"1天2小时":           26 * time.Hour,
+		"1天2小时30分钟":       26*time.Hour + 30*time.Minute,
+		"3个小时":            3 * time.Hour,
+		"2周":              2 * Week,
+		"1星期":             Week,
+		"10秒":             10 * time.Second,
+		"500毫秒":           500 * time.Millisecond,
+		"1时30分":           90 * time.Minute,
+		" 1d ":            Day,
+	}
+	for s, expect := range cases {
+		d, err := tt.ParseDuration(s)
+		assert.Nil(t, err, s)
+		assert.Equal(t, expect, d, s)
+	}
+
+	for _, bad := range []string{"", "-", "d", "1", "1mo", "1x", "1.2.3h", "h1", "1d2", "999999999999d",
+		"9223372036.854775808s", "9223372036854775808ns", "2562048h"} {
+		_, err := tt.ParseDuration(bad)
+		assert.NotNil(t, err, bad)
+	}
+
+	// 边界值
+	d, err := tt.ParseDuration("9223372036s")
+	assert.Nil(t, err)
+	assert.Equal(t, 9223372036*time.Second, d)
+}
+
+func TestFormatDuration(t *testing.T) {
+	d := Day + 2*time.Hour + 3*time.Minute + 4*time.Second + 5*time.Millisecond
+	assert.Equal(t, "1d 2h 3m 4s", tt.FormatDuration(d, 0))
+	assert.Equal(t, "1d 2h", tt.FormatDuration(d, 2))
+	assert.Equal(t, "1d", tt.FormatDuration(d, 1))
+	assert.Equal(t, "-1d 2h", tt.FormatDuration(-d, 2))
+	assert.Equal(t, "1d 3m", tt.FormatDuration(Day+3*time.Minute, 2))
+	assert.Equal(t, "0s", tt.FormatDuration(0, 0))
+	assert.Equal(t, "1ms 500µs", tt.FormatDuration(1500*time.Microsecond, 0))
+	assert.Equal(t, "15ns", tt.FormatDuration(15, 0))
+
+	// 格式化结果可被 ParseDuration 解析
+	parsed, err := tt.ParseDuration(tt.FormatDuration(d, 0))
+	assert.Nil(t, err)
+	assert.Equal(t, d.Truncate(time.Second), parsed)
+}
+
+func TestPeriod(t *testing.T) {
+	cases := map[string]Period{
+		"P1Y2M3DT4H5M6S": {1, 2, 3, 4*time.Hour + 5*time.Minute + 6*time.Second},
+		"P2W":            {Days: 14},
+		"PT1.5S":         {Time: 1500 * time.Millisecond},
+		"PT0,5H":         {Time: 30 * time.Minute},
+		"-P1D":           {Days: -1},
+		"p1m":            {Months: 1},
+		"PT36H":          {Time: 36 * time.Hour},
+	}
+	for s, expect := range cases {
+		p, err := tt.ParsePeriod(s)
+		assert.Nil(t, err, s)
+		assert.Equal(t, expect, p, s)
+	}
+
+	for _, bad := range []string{"", "P", "PT", "1D", "P1H", "PT1D", "P1DT", "P1.5D", "PTT1H", "P1", "PxD",
+		"PT9999999999H", "PT2562047H60M", "PT153722867281M", "P9223372036854775807W", "P9223372036854775807D1D"} {
+		_, err := tt.ParsePeriod(bad)
+		assert.NotNil(t, err, bad)
+	}
+
+	assert.Equal(t, "P1Y2M3DT4H5M6S", Period{1, 2, 3, 4*time.Hour + 5*time.Minute + 6*time.Second}.String())
+	assert.Equal(t, "PT1.5S", Period{Time: 1500 * time.Millisecond}.String())
+	assert.Equal(t, "-P1DT1H", Period{Days: -1, Time: -time.Hour}.String())
+	assert.Equal(t, "PT0S", Period{}.String())
+	assert.True(t, Period{}.IsZero())
+
+	start := time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC), Period{Months: 1, Days: 1, Time: 2 * time.Hour}.AddTo(start))
+	assert.Equal(t, time.Date(2025, 2, 28, 10, 0, 0, 0, time.UTC), Period{Years: 1, Months: 1}.AddTo(start))
+}
+
+func TestISODuration(t *testing.T) {
+	d, err := tt.ParseISODuration("P1DT2H")
+	assert.Nil(t, err)
+	assert.Equal(t, 26*time.Hour, d)
+
+	d, err = tt.ParseISODuration("P1W")
+	assert.Nil(t, err)
+	assert.Equal(t, Week, d)
+
+	_, err = tt.ParseISODuration("P1M")
+	assert.NotNil(t, err)
+	_, err = tt.ParseISODuration("bad")
+	assert.NotNil(t, err)
+	_, err = tt.ParseISODuration("P999999999D")
+	assert.NotNil(t, err)
+	_, err = tt.ParseISODuration("P106751DT24H")
+	assert.NotNil(t, err)
+	d, err = tt.ParseISODuration("-P106751D")
+	assert.Nil(t, err)
+	assert.Equal(t, -106751*Day, d)
+
+	assert.Equal(t, "P1DT2H", tt.ISODuration(26*time.Hour))
+	assert.Equal(t, "PT1H30M0.25S", tt.ISODuration(90*time.Minute+250*time.Millisecond))
+	assert.Equal(t, "-PT5M", tt.ISODuration(-5*time.Minute))
+	assert.Equal(t, "P2D", tt.ISODuration(2*Day))
+	assert.Equal(t, "PT0S", tt.ISODuration(0))
+}
@@ -4,6 +4,8 @@ import (
 	"math"
 	"sort"
 	"strconv"
+
+	"github.com/arnoluo/gu/random"
 )
 
 // If 根据条件判断返回不同的值。
@@ -194,3 +196,8 @@ func (ft FloatType) SortAndBinSearch(value float64, arr []float64) int {
 	ft.ArrayAsc(tmp)
 	return ft.BinSearch(value, tmp, true)
 }
+
+// 返回 [min, max) 的随机浮点数，使用 random.Default()
+func (ft FloatType) Rand(min, max float64) float64 {
+	return random.Default().Float(min, max)
+}
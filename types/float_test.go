@@ -85,3 +85,10 @@ func TestFloatSort(t *testing.T) {
 	assert.Equal(t, arrDesc, arr)
 	fmt.Println(arr)
 }
+
+func TestFloatRand(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		v := ft.Rand(0.5, 1.5)
+		assert.True(t, v >= 0.5 && v < 1.5)
+	}
+}
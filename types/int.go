@@ -7,6 +7,8 @@ import (
 	"reflect"
 	"sort"
 	"strconv"
+
+	"github.com/arnoluo/gu/random"
 )
 
 // If 根据条件判断返回不同的值。
@@ -327,3 +329,8 @@ func (it IntType) SortAndBinSearch(value int64, arr []int64) int {
 	it.ArrayAsc(tmp)
 	return it.BinSearch(value, tmp, true)
 }
+
+// 返回 [min, max] 的无偏随机数，使用 random.Default()，max < min 时 panic
+func (it IntType) Rand(min, max int64) int64 {
+	return random.Default().Int64(min, max)
+}
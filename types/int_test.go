@@ -169,3 +169,11 @@ func TestIntSort(t *testing.T) {
 	assert.Equal(t, arrDesc, arr)
 	fmt.Println(arr)
 }
+
+func TestIntRand(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		v := it.Rand(-3, 3)
+		assert.True(t, v >= -3 && v <= 3)
+	}
+	assert.Equal(t, int64(5), it.Rand(5, 5))
+}
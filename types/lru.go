@@ -0,0 +1,70 @@
+package types
+
+import (
+	"container/list"
+	"sync"
+)
+
+// 并发安全的 LRU 缓存，size 为 0 时不缓存
+type lruCache[K comparable, V any] struct {
+	mu    sync.Mutex
+	size  int
+	ll    *list.List
+	items map[K]*list.Element
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+func newLRUCache[K comparable, V any](size int) *lruCache[K, V] {
+	return &lruCache[K, V]{size: size, ll: list.New(), items: map[K]*list.Element{}}
+}
+
+func (c *lruCache[K, V]) get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		c.ll.MoveToFront(el)
+		return el.Value.(*lruEntry[K, V]).value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// 添加缓存项，key 已存在时保留并返回已有的值
+func (c *lruCache[K, V]) add(key K, value V) V {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		c.ll.MoveToFront(el)
+		return el.Value.(*lruEntry[K, V]).value
+	}
+	if c.size > 0 {
+		c.items[key] = c.ll.PushFront(&lruEntry[K, V]{key: key, value: value})
+		c.evict()
+	}
+	return value
+}
+
+// 设置容量，返回之前的容量
+func (c *lruCache[K, V]) setSize(size int) int {
+	if size < 0 {
+		size = 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	prev := c.size
+	c.size = size
+	c.evict()
+	return prev
+}
+
+func (c *lruCache[K, V]) evict() {
+	for c.ll.Len() > c.size {
+		el := c.ll.Back()
+		c.ll.Remove(el)
+		delete(c.items, el.Value.(*lruEntry[K, V]).key)
+	}
+}
@@ -2,13 +2,12 @@ package types
 
 import (
 	"fmt"
-	"math/rand"
-	"regexp"
 	"sort"
 	"strconv"
 	"strings"
-	"time"
 	"unicode"
+
+	"github.com/arnoluo/gu/random"
 )
 
 const (
@@ -115,9 +114,14 @@ func (st StrType) If(boolValue bool, trueValue, falseValue string) string {
 }
 
 // Replace baseStr with regexpPattern to replacement
+//
+// 正则表达式无效时 panic，需要返回错误时使用 RegReplaceE
 func (st StrType) RegReplace(baseStr, regexpPattern, replacement string) string {
-	reg := regexp.MustCompile(regexpPattern)
-	return reg.ReplaceAllString(baseStr, replacement)
+	s, err := st.RegReplaceE(baseStr, regexpPattern, replacement)
+	if err != nil {
+		panic(err)
+	}
+	return s
 }
 
 // Generate random str base on letter&number mixed chars
@@ -135,14 +139,11 @@ func (st StrType) RandNumbers(length int) string {
 	return st.RandChars(PURE_NUMBER_CHARS, length)
 }
 
-// Generate random str base on baseChars
-func (st StrType) RandChars(chars string, length int) (str string) {
-	for i := 0; i < length; i++ {
-		rand.Seed(time.Now().UnixNano())
-		randPos := rand.Intn(len(chars))
-		str += chars[randPos : randPos+1]
-	}
-	return
+// Generate random str base on baseChars, chars 可包含多字节字符
+//
+// 使用 random.Default() 生成，默认来源为 crypto/rand，测试中可通过 random.SetDefault 设置固定种子
+func (st StrType) RandChars(chars string, length int) string {
+	return random.Default().String(chars, length)
 }
 
 // 返回字符串的长度
@@ -0,0 +1,235 @@
+package types
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// 默认缩略词，Camel / Pascal / Title 输出时保持全大写
+var defaultAcronyms = []string{
+	"ACL", "API", "ASCII", "CPU", "CSS", "CSV", "DB", "DNS", "EOF", "GUID", "HTML", "HTTP", "HTTPS",
+	"ID", "IP", "JSON", "JWT", "OS", "QPS", "RAM", "RPC", "SDK", "SLA", "SMTP", "SQL", "SSH", "TCP",
+	"TLS", "TTL", "UDP", "UI", "UID", "URI", "URL", "UTF8", "UUID", "VM", "XML", "XSRF", "XSS",
+}
+
+var (
+	acronymMu sync.RWMutex
+	acronyms  = acronymSet(defaultAcronyms)
+)
+
+func acronymSet(words []string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		if w = strings.TrimSpace(w); w != "" {
+			set[strings.ToUpper(w)] = true
+		}
+	}
+	return set
+}
+
+// 设置缩略词列表，传入 nil 时恢复默认列表
+//
+// 返回之前的列表，便于测试结束后还原
+func (st StrType) SetAcronyms(words []string) []string {
+	if words == nil {
+		words = defaultAcronyms
+	}
+	set := acronymSet(words)
+
+	acronymMu.Lock()
+	defer acronymMu.Unlock()
+	prev := sortedAcronyms(acronyms)
+	acronyms = set
+	return prev
+}
+
+// 添加缩略词
+func (st StrType) AddAcronyms(words ...string) {
+	acronymMu.Lock()
+	defer acronymMu.Unlock()
+	set := acronymSet(words)
+	for w := range acronyms {
+		set[w] = true
+	}
+	acronyms = set
+}
+
+// 返回当前的缩略词列表(升序)
+func (st StrType) Acronyms() []string {
+	acronymMu.RLock()
+	defer acronymMu.RUnlock()
+	return sortedAcronyms(acronyms)
+}
+
+func sortedAcronyms(set map[string]bool) []string {
+	words := make([]string, 0, len(set))
+	for w := range set {
+		words = append(words, w)
+	}
+	sort.Strings(words)
+	return words
+}
+
+func isAcronym(word string) bool {
+	acronymMu.RLock()
+	defer acronymMu.RUnlock()
+	return acronyms[strings.ToUpper(word)]
+}
+
+// 将字符串拆分为单词，非字母数字字符视为分隔符
+//
+// 识别大小写边界与连续大写缩略词: HTTPServerID => [HTTP Server ID]、userIDs => [user IDs]，
+// 数字归属前一个单词: Int64Value => [Int64 Value]，连续大写可由缩略词列表拆分: JSONAPI => [JSON API]
+func (st StrType) Words(str string) []string {
+	runes := []rune(str)
+	n := len(runes)
+	isSep := func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }
+	isTail := func(r rune) bool { return !isSep(r) && !unicode.IsUpper(r) }
+
+	var words []string
+	for i := 0; i < n; {
+		if isSep(runes[i]) {
+			i++
+			continue
+		}
+
+		start := i
+		if !unicode.IsUpper(runes[i]) {
+			for i < n && isTail(runes[i]) {
+				i++
+			}
+			words = append(words, string(runes[start:i]))
+			continue
+		}
+
+		j := i
+		for j < n && unicode.IsUpper(runes[j]) {
+			j++
+		}
+		if j-i == 1 {
+			// 首字母大写的单词
+			for i = j; i < n && isTail(runes[i]); i++ {
+			}
+			words = append(words, string(runes[start:i]))
+			continue
+		}
+
+		switch {
+		case j < n && runes[j] == 's' && (j+1 == n || !unicode.IsLower(runes[j+1])):
+			// 复数缩略词 IDs
+			words = append(words, splitAcronyms(string(runes[start:j]))...)
+			words[len(words)-1] += "s"
+			i = j + 1
+		case j < n && unicode.IsLower(runes[j]):
+			// 最后一个大写字母属于下一个单词
+			words = append(words, splitAcronyms(string(runes[start:j-1]))...)
+			i = j - 1
+		default:
+			for i = j; i < n && unicode.IsDigit(runes[i]); i++ {
+			}
+			words = append(words, splitAcronyms(string(runes[start:i]))...)
+		}
+	}
+	return words
+}
+
+// 连续大写能完整拆分为多个已知缩略词时拆分，否则保持原样
+func splitAcronyms(run string) []string {
+	acronymMu.RLock()
+	defer acronymMu.RUnlock()
+	if acronyms[run] {
+		return []string{run}
+	}
+	if parts := segmentAcronyms(run); len(parts) > 0 {
+		return parts
+	}
+	return []string{run}
+}
+
+func segmentAcronyms(run string) []string {
+	if run == "" {
+		return []string{}
+	}
+	// 优先匹配较长的缩略词
+	for i := len(run); i > 0; i-- {
+		if !acronyms[run[:i]] {
+			continue
+		}
+		if rest := segmentAcronyms(run[i:]); rest != nil {
+			return append([]string{run[:i]}, rest...)
+		}
+	}
+	return nil
+}
+
+func (st StrType) joinLower(str, sep string) string {
+	words := st.Words(str)
+	for i, w := range words {
+		words[i] = strings.ToLower(w)
+	}
+	return strings.Join(words, sep)
+}
+
+// 转为首字母大写的单词，缩略词保持全大写
+func (st StrType) capitalize(word string) string {
+	if isAcronym(word) {
+		return strings.ToUpper(word)
+	}
+	if strings.HasSuffix(word, "s") && len(word) > 2 && isAcronym(word[:len(word)-1]) {
+		return strings.ToUpper(word[:len(word)-1]) + "s"
+	}
+	return st.UpperFirst(strings.ToLower(word))
+}
+
+// 转为 snake_case: HTTPServerID => http_server_id
+func (st StrType) Snake(str string) string {
+	return st.joinLower(str, "_")
+}
+
+// 转为 SCREAMING_SNAKE_CASE: HTTPServerID => HTTP_SERVER_ID
+func (st StrType) ScreamingSnake(str string) string {
+	return strings.ToUpper(st.joinLower(str, "_"))
+}
+
+// 转为 kebab-case: HTTPServerID => http-server-id
+func (st StrType) Kebab(str string) string {
+	return st.joinLower(str, "-")
+}
+
+// 转为 dot.case: HTTPServerID => http.server.id
+func (st StrType) Dot(str string) string {
+	return st.joinLower(str, ".")
+}
+
+// 转为 camelCase，缩略词保持全大写: http_server_id => httpServerID
+func (st StrType) Camel(str string) string {
+	words := st.Words(str)
+	for i, w := range words {
+		if i == 0 {
+			words[i] = strings.ToLower(w)
+		} else {
+			words[i] = st.capitalize(w)
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// 转为 PascalCase，缩略词保持全大写: http_server_id => HTTPServerID
+func (st StrType) Pascal(str string) string {
+	words := st.Words(str)
+	for i, w := range words {
+		words[i] = st.capitalize(w)
+	}
+	return strings.Join(words, "")
+}
+
+// 转为以空格分隔、首字母大写的标题，缩略词保持全大写: http_server_id => HTTP Server ID
+func (st StrType) Title(str string) string {
+	words := st.Words(str)
+	for i, w := range words {
+		words[i] = st.capitalize(w)
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,67 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWords(t *testing.T) {
+	cases := map[string][]string{
+		"HTTPServerID":      {"HTTP", "Server", "ID"},
+		"httpServerId":      {"http", "Server", "Id"},
+		"http_server_id":    {"http", "server", "id"},
+		"  Hello, world!  ": {"Hello", "world"},
+		"userIDs":           {"user", "IDs"},
+		"Int64Value":        {"Int64", "Value"},
+		"UTF8String":        {"UTF8", "String"},
+		"base64Encode":      {"base64", "Encode"},
+		"JSONAPIResponse":   {"JSON", "API", "Response"},
+		"ABCDef":            {"ABC", "Def"},
+		"用户ID":              {"用户", "ID"},
+		"ÉcoleNormale":      {"École", "Normale"},
+		"version2-beta.1":   {"version2", "beta", "1"},
+		"":                  nil,
+	}
+	for in, want := range cases {
+		assert.Equal(t, want, st.Words(in), in)
+	}
+}
+
+func TestCase(t *testing.T) {
+	assert.Equal(t, "http_server_id", st.Snake("HTTPServerID"))
+	assert.Equal(t, "HTTP_SERVER_ID", st.ScreamingSnake("httpServerId"))
+	assert.Equal(t, "http-server-id", st.Kebab("HTTP server ID"))
+	assert.Equal(t, "http.server.id", st.Dot("http-server-id"))
+	assert.Equal(t, "httpServerID", st.Camel("http_server_id"))
+	assert.Equal(t, "HTTPServerID", st.Pascal("http_server_id"))
+	assert.Equal(t, "HTTP Server ID", st.Title("http_server_id"))
+
+	assert.Equal(t, "userIDs", st.Camel("user_ids"))
+	assert.Equal(t, "user_ids", st.Snake("UserIDs"))
+	assert.Equal(t, "CreatedAt", st.Pascal("created_at"))
+	assert.Equal(t, "用户Name", st.Camel("用户_name"))
+	assert.Equal(t, "", st.Snake("__"))
+
+	// 往返转换
+	for _, s := range []string{"HTTPServerID", "UserIDs", "JSONAPIResponse", "UTF8String"} {
+		assert.Equal(t, s, st.Pascal(st.Snake(s)), s)
+	}
+}
+
+func TestAcronyms(t *testing.T) {
+	assert.Contains(t, st.Acronyms(), "HTTP")
+	assert.Equal(t, "OauthToken", st.Pascal("oauth_token"))
+
+	st.AddAcronyms("oauth")
+	assert.Equal(t, "OAUTHToken", st.Pascal("oauth_token"))
+
+	prev := st.SetAcronyms([]string{"Gu"})
+	assert.Contains(t, prev, "OAUTH")
+	assert.Equal(t, []string{"GU"}, st.Acronyms())
+	assert.Equal(t, "HttpGU", st.Pascal("http_gu"))
+
+	st.SetAcronyms(nil)
+	assert.NotContains(t, st.Acronyms(), "OAUTH")
+	assert.Equal(t, "HTTPGu", st.Pascal("http_gu"))
+}
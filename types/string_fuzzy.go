@@ -0,0 +1,220 @@
+package types
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// FuzzyFind 的匹配结果
+type FuzzyMatch struct {
+	Value string
+	// 在候选列表中的下标
+	Index int
+	// 相似度 [0, 1]，1 表示完全相同
+	Score float64
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// 编辑距离(Levenshtein)，按字符(rune)计算插入、删除、替换的最少次数
+func (st StrType) Levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < len(rb) {
+		ra, rb = rb, ra
+	}
+
+	// 只保留一行，空间 O(min(m, n))
+	row := make([]int, len(rb)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		prev := row[0]
+		row[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur := minInt(minInt(row[j]+1, row[j-1]+1), prev+cost)
+			prev, row[j] = row[j], cur
+		}
+	}
+	return row[len(rb)]
+}
+
+// Damerau-Levenshtein 距离，在 Levenshtein 的基础上将相邻字符交换计为一次编辑: ab => ba 距离为 1
+func (st StrType) Damerau(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	m, n := len(ra), len(rb)
+	inf := m + n
+
+	// d[i+1][j+1] 为 ra[:i] 与 rb[:j] 的距离，首行首列为哨兵
+	d := make([][]int, m+2)
+	for i := range d {
+		d[i] = make([]int, n+2)
+	}
+	d[0][0] = inf
+	for i := 0; i <= m; i++ {
+		d[i+1][0] = inf
+		d[i+1][1] = i
+	}
+	for j := 0; j <= n; j++ {
+		d[0][j+1] = inf
+		d[1][j+1] = j
+	}
+
+	last := map[rune]int{}
+	for i := 1; i <= m; i++ {
+		db := 0
+		for j := 1; j <= n; j++ {
+			i1, j1 := last[rb[j-1]], db
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+				db = j
+			}
+			d[i+1][j+1] = minInt(
+				minInt(d[i][j]+cost, d[i+1][j]+1),
+				minInt(d[i][j+1]+1, d[i1][j1]+(i-i1-1)+1+(j-j1-1)),
+			)
+		}
+		last[ra[i-1]] = i
+	}
+	return d[m+1][n+1]
+}
+
+// Jaro 相似度 [0, 1]
+func (st StrType) Jaro(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 && len(rb) == 0 {
+		return 1
+	}
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+
+	window := len(ra)
+	if len(rb) > window {
+		window = len(rb)
+	}
+	window = window/2 - 1
+	if window < 0 {
+		window = 0
+	}
+
+	matchA := make([]bool, len(ra))
+	matchB := make([]bool, len(rb))
+	matches := 0
+	for i, r := range ra {
+		lo, hi := i-window, minInt(i+window+1, len(rb))
+		if lo < 0 {
+			lo = 0
+		}
+		for j := lo; j < hi; j++ {
+			if !matchB[j] && rb[j] == r {
+				matchA[i], matchB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions := 0
+	j := 0
+	for i, r := range ra {
+		if !matchA[i] {
+			continue
+		}
+		for !matchB[j] {
+			j++
+		}
+		if r != rb[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	return (m/float64(len(ra)) + m/float64(len(rb)) + (m-float64(transpositions)/2)/m) / 3
+}
+
+// Jaro-Winkler 相似度 [0, 1]，对相同前缀(最多 4 个字符)给予加分，适用于短字符串如姓名、命令名
+func (st StrType) JaroWinkler(a, b string) float64 {
+	sim := st.Jaro(a, b)
+	prefix := 0
+	for prefix < 4 && len(a) > 0 && len(b) > 0 {
+		ra, na := utf8.DecodeRuneInString(a)
+		rb, nb := utf8.DecodeRuneInString(b)
+		if ra != rb {
+			break
+		}
+		prefix++
+		a, b = a[na:], b[nb:]
+	}
+	return sim + float64(prefix)*0.1*(1-sim)
+}
+
+// 基于编辑距离的相似度 [0, 1]: 1 - Levenshtein / 较长字符串的字符数
+func (st StrType) Similarity(a, b string) float64 {
+	n := utf8.RuneCountInString(a)
+	if m := utf8.RuneCountInString(b); m > n {
+		n = m
+	}
+	if n == 0 {
+		return 1
+	}
+	return 1 - float64(st.Levenshtein(a, b))/float64(n)
+}
+
+// 返回与 value 最接近的候选项(忽略大小写，按 Damerau 距离)，距离超过 maxDistance 或无候选项时返回 false
+//
+// 距离相同时返回靠前的候选项，适用于 "did you mean" 提示
+func (st StrType) ClosestMatch(value string, candidates []string, maxDistance int) (string, bool) {
+	value = strings.ToLower(value)
+	best, bestDist := -1, maxDistance+1
+	for i, c := range candidates {
+		if d := st.Damerau(value, strings.ToLower(c)); d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	if best < 0 {
+		return "", false
+	}
+	return candidates[best], true
+}
+
+// 模糊查找，返回相似度不低于 minScore 的候选项，按相似度降序排列(相同时保持原顺序)
+//
+// 忽略大小写，相似度取 Jaro-Winkler，候选项包含 value 时不低于 0.8 并随覆盖比例提高
+func (st StrType) FuzzyFind(value string, candidates []string, minScore float64) []FuzzyMatch {
+	value = strings.ToLower(value)
+	valueLen := utf8.RuneCountInString(value)
+
+	matches := []FuzzyMatch{}
+	for i, c := range candidates {
+		lower := strings.ToLower(c)
+		score := st.JaroWinkler(value, lower)
+		if valueLen > 0 && strings.Contains(lower, value) {
+			if s := 0.8 + 0.2*float64(valueLen)/float64(utf8.RuneCountInString(lower)); s > score {
+				score = s
+			}
+		}
+		if score >= minScore {
+			matches = append(matches, FuzzyMatch{Value: c, Index: i, Score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	return matches
+}
@@ -0,0 +1,67 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 3, st.Levenshtein("kitten", "sitting"))
+	assert.Equal(t, 0, st.Levenshtein("", ""))
+	assert.Equal(t, 3, st.Levenshtein("", "abc"))
+	assert.Equal(t, 2, st.Levenshtein("ab", "ba"))
+	assert.Equal(t, 1, st.Levenshtein("中文", "中午"))
+
+	assert.Equal(t, 1, st.Damerau("ab", "ba"))
+	assert.Equal(t, 3, st.Damerau("kitten", "sitting"))
+	assert.Equal(t, 2, st.Damerau("ca", "abc"))
+	assert.Equal(t, 1, st.Damerau("stauts", "status"))
+	assert.Equal(t, 1, st.Damerau("文中", "中文"))
+	assert.Equal(t, 4, st.Damerau("", "abcd"))
+
+	assert.InDelta(t, 0.5, st.Similarity("ab", "ac"), 1e-9)
+	assert.Equal(t, 1.0, st.Similarity("", ""))
+}
+
+func TestJaroWinkler(t *testing.T) {
+	assert.InDelta(t, 0.944, st.Jaro("MARTHA", "MARHTA"), 0.001)
+	assert.InDelta(t, 0.961, st.JaroWinkler("MARTHA", "MARHTA"), 0.001)
+	assert.InDelta(t, 0.767, st.Jaro("DIXON", "DICKSONX"), 0.001)
+	assert.InDelta(t, 0.813, st.JaroWinkler("DIXON", "DICKSONX"), 0.001)
+	assert.Equal(t, 1.0, st.JaroWinkler("同一个", "同一个"))
+	assert.Equal(t, 0.0, st.Jaro("abc", ""))
+	assert.Equal(t, 0.0, st.Jaro("abc", "xyz"))
+	assert.Equal(t, 1.0, st.Jaro("", ""))
+}
+
+func TestClosestMatch(t *testing.T) {
+	commands := []string{"status", "commit", "checkout", "push", "pull"}
+	m, ok := st.ClosestMatch("stauts", commands, 2)
+	assert.True(t, ok)
+	assert.Equal(t, "status", m)
+
+	m, ok = st.ClosestMatch("PUHS", commands, 1)
+	assert.True(t, ok)
+	assert.Equal(t, "push", m)
+
+	_, ok = st.ClosestMatch("deploy", commands, 2)
+	assert.False(t, ok)
+	_, ok = st.ClosestMatch("a", nil, 5)
+	assert.False(t, ok)
+}
+
+func TestFuzzyFind(t *testing.T) {
+	items := []string{"UserService", "OrderService", "user_repo", "Payment", "Users"}
+	matches := st.FuzzyFind("user", items, 0.8)
+	var values []string
+	for _, m := range matches {
+		values = append(values, m.Value)
+		assert.True(t, m.Score >= 0.8 && m.Score <= 1)
+		assert.Equal(t, items[m.Index], m.Value)
+	}
+	assert.Equal(t, []string{"Users", "user_repo", "UserService"}, values)
+
+	assert.Len(t, st.FuzzyFind("user", items, 0), len(items))
+	assert.Empty(t, st.FuzzyFind("zzz", items, 0.9))
+}
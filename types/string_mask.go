@@ -0,0 +1,140 @@
+package types
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// 保留 keepLeft 与 keepRight 的字符数，至少遮盖 1 个字符
+func maskKeep(n, keepLeft, keepRight int) (int, int) {
+	if keepLeft < 0 {
+		keepLeft = 0
+	}
+	if keepRight < 0 {
+		keepRight = 0
+	}
+	if keepLeft+keepRight >= n {
+		// 字符过少时优先减少右侧保留
+		keepRight = n - 1 - keepLeft
+		if keepRight < 0 {
+			keepRight = 0
+			keepLeft = n - 1
+			if keepLeft < 0 {
+				keepLeft = 0
+			}
+		}
+	}
+	return keepLeft, keepRight
+}
+
+// 遮盖字符串，保留左侧 keepLeft 个与右侧 keepRight 个字符(按 rune 计算)，其余替换为 maskChar
+//
+// 字符数不超过 keepLeft + keepRight 时减少保留的字符，至少遮盖 1 个字符
+func (st StrType) Mask(str string, keepLeft, keepRight int, maskChar rune) string {
+	runes := []rune(str)
+	n := len(runes)
+	if n == 0 {
+		return ""
+	}
+	keepLeft, keepRight = maskKeep(n, keepLeft, keepRight)
+	for i := keepLeft; i < n-keepRight; i++ {
+		runes[i] = maskChar
+	}
+	return string(runes)
+}
+
+// 只遮盖数字，保留左侧 keepLeft 个与右侧 keepRight 个数字，空格、连字符等格式字符保持不变
+func maskDigits(str string, keepLeft, keepRight int) string {
+	runes := []rune(str)
+	n := 0
+	for _, r := range runes {
+		if unicode.IsDigit(r) {
+			n++
+		}
+	}
+	if n == 0 {
+		return StrType{}.Mask(str, keepLeft, keepRight, '*')
+	}
+
+	keepLeft, keepRight = maskKeep(n, keepLeft, keepRight)
+	i := 0
+	for j, r := range runes {
+		if !unicode.IsDigit(r) {
+			continue
+		}
+		if i >= keepLeft && i < n-keepRight {
+			runes[j] = '*'
+		}
+		i++
+	}
+	return string(runes)
+}
+
+// 遮盖手机号，保留前 3 位与后 4 位数字: 13812345678 => 138****5678
+//
+// 带国家码时同样只保留前 3 位与后 4 位数字: +86 138 1234 5678 => +86 1** **** 5678
+func (st StrType) MaskPhone(phone string) string {
+	return maskDigits(phone, 3, 4)
+}
+
+// 遮盖邮箱用户名，保留首尾字符: zhangsan@example.com => z******n@example.com
+func (st StrType) MaskEmail(email string) string {
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return st.Mask(email, 1, 1, '*')
+	}
+	return st.Mask(email[:at], 1, 1, '*') + email[at:]
+}
+
+// 遮盖身份证号(18 位或 15 位)，保留前 6 位地区码与后 4 位: 110101199003071234 => 110101********1234
+func (st StrType) MaskIDCard(id string) string {
+	return st.Mask(id, 6, 4, '*')
+}
+
+// 遮盖银行卡号，保留前 6 位与后 4 位数字，空格与连字符保持不变: 6222 0212 3456 7890 => 6222 02** **** 7890
+func (st StrType) MaskBankCard(card string) string {
+	return maskDigits(card, 6, 4)
+}
+
+// 遮盖姓名: 两个字保留姓氏，三个字及以上保留首尾，如 张三 => 张*、欧阳锋 => 欧*锋；含空格的姓名每个单词保留首字母
+func (st StrType) MaskName(name string) string {
+	if words := strings.Fields(name); len(words) > 1 {
+		for i, w := range words {
+			words[i] = st.Mask(w, 1, 0, '*')
+		}
+		return strings.Join(words, " ")
+	}
+
+	name = strings.TrimSpace(name)
+	if n := len([]rune(name)); n <= 2 {
+		return st.Mask(name, 1, 0, '*')
+	}
+	return st.Mask(name, 1, 1, '*')
+}
+
+// 按 mask 标签遮盖字符串，未知标签遮盖全部字符
+//
+// 标签: phone、email、idcard、bankcard、name、all，或 "左侧保留数,右侧保留数" 如 "3,4"
+func (st StrType) maskByTag(str, tag string) string {
+	switch tag {
+	case "phone":
+		return st.MaskPhone(str)
+	case "email":
+		return st.MaskEmail(str)
+	case "idcard":
+		return st.MaskIDCard(str)
+	case "bankcard":
+		return st.MaskBankCard(str)
+	case "name":
+		return st.MaskName(str)
+	}
+	if l, r, ok := strings.Cut(tag, ","); ok {
+		left, err1 := strconv.Atoi(strings.TrimSpace(l))
+		right, err2 := strconv.Atoi(strings.TrimSpace(r))
+		if err1 == nil && err2 == nil {
+			return st.Mask(str, left, right, '*')
+		}
+	}
+	return strings.Repeat("*", len([]rune(str)))
+}
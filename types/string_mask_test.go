@@ -0,0 +1,40 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMask(t *testing.T) {
+	assert.Equal(t, "ab***fg", st.Mask("abcdefg", 2, 2, '*'))
+	assert.Equal(t, "中**国", st.Mask("中华民国", 1, 1, '*'))
+	assert.Equal(t, "a#", st.Mask("ab", 1, 1, '#'))
+	assert.Equal(t, "*", st.Mask("a", 1, 1, '*'))
+	assert.Equal(t, "ab*", st.Mask("abc", 5, 0, '*'))
+	assert.Equal(t, "***", st.Mask("abc", -1, -1, '*'))
+	assert.Equal(t, "", st.Mask("", 1, 1, '*'))
+}
+
+func TestMaskPII(t *testing.T) {
+	assert.Equal(t, "138****5678", st.MaskPhone("13812345678"))
+	assert.Equal(t, "+86 1** **** 5678", st.MaskPhone("+86 138 1234 5678"))
+	assert.Equal(t, "123*", st.MaskPhone("1234"))
+
+	assert.Equal(t, "z******n@example.com", st.MaskEmail("zhangsan@example.com"))
+	assert.Equal(t, "a*@b.c", st.MaskEmail("ab@b.c"))
+	assert.Equal(t, "*@b.c", st.MaskEmail("a@b.c"))
+	assert.Equal(t, "n*****l", st.MaskEmail("notmail"))
+
+	assert.Equal(t, "110101********123X", st.MaskIDCard("11010119900307123X"))
+	assert.Equal(t, "110101*****1234", st.MaskIDCard("110101900301234"))
+
+	assert.Equal(t, "622202******7890", st.MaskBankCard("6222021234567890"))
+	assert.Equal(t, "6222 02** **** 7890", st.MaskBankCard("6222 0212 3456 7890"))
+
+	assert.Equal(t, "张*", st.MaskName("张三"))
+	assert.Equal(t, "欧*锋", st.MaskName("欧阳锋"))
+	assert.Equal(t, "司**如", st.MaskName("司马相如"))
+	assert.Equal(t, "*", st.MaskName("张"))
+	assert.Equal(t, "J*** S****", st.MaskName("John  Smith"))
+}
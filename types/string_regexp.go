@@ -0,0 +1,86 @@
+package types
+
+import "regexp"
+
+// 默认缓存的正则表达式个数
+const defaultRegCacheSize = 256
+
+// 已编译正则表达式的 LRU 缓存
+var regexps = newLRUCache[string, *regexp.Regexp](defaultRegCacheSize)
+
+// 设置正则表达式缓存容量，0 表示不缓存，返回之前的容量
+func (st StrType) SetRegCacheSize(size int) int {
+	return regexps.setSize(size)
+}
+
+// 返回编译后的正则表达式，结果被缓存，可安全地并发使用
+func (st StrType) Regexp(pattern string) (*regexp.Regexp, error) {
+	if re, ok := regexps.get(pattern); ok {
+		return re, nil
+	}
+
+	// 编译不持有锁，并发编译同一表达式时结果等价
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return regexps.add(pattern, re), nil
+}
+
+// 同 RegReplace，正则表达式无效时返回错误
+func (st StrType) RegReplaceE(baseStr, regexpPattern, replacement string) (string, error) {
+	re, err := st.Regexp(regexpPattern)
+	if err != nil {
+		return baseStr, err
+	}
+	return re.ReplaceAllString(baseStr, replacement), nil
+}
+
+// 以 fn 的返回值替换所有匹配项
+func (st StrType) RegReplaceFunc(baseStr, regexpPattern string, fn func(string) string) (string, error) {
+	re, err := st.Regexp(regexpPattern)
+	if err != nil {
+		return baseStr, err
+	}
+	return re.ReplaceAllStringFunc(baseStr, fn), nil
+}
+
+// 判断 str 是否匹配正则表达式
+func (st StrType) RegMatch(str, regexpPattern string) (bool, error) {
+	re, err := st.Regexp(regexpPattern)
+	if err != nil {
+		return false, err
+	}
+	return re.MatchString(str), nil
+}
+
+// 返回最多 n 个匹配项，n < 0 时返回全部
+func (st StrType) RegFindAll(str, regexpPattern string, n int) ([]string, error) {
+	re, err := st.Regexp(regexpPattern)
+	if err != nil {
+		return nil, err
+	}
+	return re.FindAllString(str, n), nil
+}
+
+// 返回首个匹配项中命名分组的值，如 `(?P<year>\d{4})-(?P<month>\d{2})`，未匹配时返回 nil
+//
+// 未参与匹配的分组值为空字符串
+func (st StrType) RegSubmatchMap(str, regexpPattern string) (map[string]string, error) {
+	re, err := st.Regexp(regexpPattern)
+	if err != nil {
+		return nil, err
+	}
+	match := re.FindStringSubmatch(str)
+	if match == nil {
+		return nil, nil
+	}
+
+	groups := map[string]string{}
+	for i, name := range re.SubexpNames() {
+		if i > 0 && name != "" {
+			groups[name] = match[i]
+		}
+	}
+	return groups, nil
+}
@@ -0,0 +1,91 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegHelpers(t *testing.T) {
+	s, err := st.RegReplaceE("a1b22c333", `\d+`, "#")
+	assert.Nil(t, err)
+	assert.Equal(t, "a#b#c#", s)
+	s, err = st.RegReplaceE("abc", `(`, "#")
+	assert.NotNil(t, err)
+	assert.Equal(t, "abc", s)
+	assert.Panics(t, func() { st.RegReplace("abc", `(`, "#") })
+
+	s, err = st.RegReplaceFunc("hello world", `\b\w`, strings.ToUpper)
+	assert.Nil(t, err)
+	assert.Equal(t, "Hello World", s)
+
+	ok, err := st.RegMatch("2024-05-01", `^\d{4}-\d{2}-\d{2}$`)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	ok, err = st.RegMatch("x", `[`)
+	assert.NotNil(t, err)
+	assert.False(t, ok)
+
+	all, err := st.RegFindAll("a1b22c333", `\d+`, -1)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1", "22", "333"}, all)
+	all, _ = st.RegFindAll("a1b22c333", `\d+`, 2)
+	assert.Equal(t, []string{"1", "22"}, all)
+	all, _ = st.RegFindAll("abc", `\d+`, -1)
+	assert.Empty(t, all)
+
+	groups, err := st.RegSubmatchMap("date: 2024-05", `(?P<year>\d{4})-(?P<month>\d{2})(?:-(?P<day>\d{2}))?`)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"year": "2024", "month": "05", "day": ""}, groups)
+	groups, err = st.RegSubmatchMap("none", `(?P<year>\d{4})`)
+	assert.Nil(t, err)
+	assert.Nil(t, groups)
+}
+
+func TestRegCache(t *testing.T) {
+	prev := st.SetRegCacheSize(2)
+	defer st.SetRegCacheSize(prev)
+
+	a, _ := st.Regexp(`a+`)
+	a2, _ := st.Regexp(`a+`)
+	assert.Same(t, a, a2)
+
+	st.Regexp(`b+`)
+	st.Regexp(`a+`) // a+ 最近使用
+	st.Regexp(`c+`) // 淘汰 b+
+	assert.Equal(t, 2, regexps.ll.Len())
+	a3, _ := st.Regexp(`a+`)
+	assert.Same(t, a, a3)
+	_, cached := regexps.items[`b+`]
+	assert.False(t, cached)
+
+	_, err := st.Regexp(`(`)
+	assert.NotNil(t, err)
+	_, cached = regexps.items[`(`]
+	assert.False(t, cached)
+
+	assert.Equal(t, 2, st.SetRegCacheSize(0))
+	assert.Equal(t, 0, regexps.ll.Len())
+	re, err := st.Regexp(`d+`)
+	assert.Nil(t, err)
+	assert.True(t, re.MatchString("ddd"))
+	assert.Equal(t, 0, regexps.ll.Len())
+
+	st.SetRegCacheSize(16)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_, err := st.RegMatch(fmt.Sprint(j), fmt.Sprintf(`^%d$`, (i*100+j)%32))
+				assert.Nil(t, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.True(t, regexps.ll.Len() <= 16)
+}
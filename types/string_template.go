@@ -0,0 +1,291 @@
+package types
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// 模板过滤器，arg 为 {name|filter:arg} 中冒号后的参数
+type TemplateFilter func(value, arg string) (string, error)
+
+var (
+	templateFilterMu sync.RWMutex
+	templateFilters  = map[string]TemplateFilter{
+		"upper": func(v, _ string) (string, error) { return strings.ToUpper(v), nil },
+		"lower": func(v, _ string) (string, error) { return strings.ToLower(v), nil },
+		"title": func(v, _ string) (string, error) { return StrType{}.UpperFirst(v), nil },
+		"trim":  func(v, _ string) (string, error) { return strings.TrimSpace(v), nil },
+		"html":  func(v, _ string) (string, error) { return html.EscapeString(v), nil },
+		"url":   func(v, _ string) (string, error) { return url.QueryEscape(v), nil },
+		"default": func(v, arg string) (string, error) {
+			if v == "" {
+				return arg, nil
+			}
+			return v, nil
+		},
+		"number": numberFilter,
+	}
+)
+
+// 注册(或覆盖)模板过滤器
+func (st StrType) RegisterTemplateFilter(name string, filter TemplateFilter) {
+	templateFilterMu.Lock()
+	defer templateFilterMu.Unlock()
+	templateFilters[name] = filter
+}
+
+func templateFilter(name string) (TemplateFilter, bool) {
+	templateFilterMu.RLock()
+	defer templateFilterMu.RUnlock()
+	f, ok := templateFilters[name]
+	return f, ok
+}
+
+// 千分位格式化数字，arg 为保留的小数位数: {price|number:2} => 1,234.50
+func numberFilter(v, arg string) (string, error) {
+	if v == "" {
+		return "", nil
+	}
+	var s string
+	if arg == "" {
+		if _, err := strconv.ParseInt(v, 10, 64); err == nil {
+			s = v
+		}
+	}
+	if s == "" {
+		var f float64
+		if err := (AnyType{}).Float(v, &f); err != nil {
+			return "", fmt.Errorf("not a number %q", v)
+		}
+		prec := -1
+		if arg != "" {
+			p, err := strconv.Atoi(arg)
+			if err != nil || p < 0 {
+				return "", fmt.Errorf("invalid precision %q", arg)
+			}
+			prec = p
+		}
+		s = strconv.FormatFloat(f, 'f', prec, 64)
+	}
+
+	sign := ""
+	if s[0] == '-' {
+		sign, s = "-", s[1:]
+	}
+	intPart, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, frac = s[:i], s[i:]
+	}
+	var b strings.Builder
+	for i, c := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(c)
+	}
+	return sign + b.String() + frac, nil
+}
+
+// 预编译的模板，可安全地并发使用
+type Template struct {
+	src      string
+	segments []templateSegment
+}
+
+type templateSegment struct {
+	text    string
+	path    []string
+	holder  string
+	hasDef  bool
+	filters []templateFilterCall
+}
+
+type templateFilterCall struct {
+	name string
+	arg  string
+	fn   TemplateFilter
+}
+
+// 编译模板，语法:
+//
+//	{name}                 取值，支持 map 与结构体(字段名、json 标签或忽略大小写的字段名)，{user.name} 取嵌套值
+//	{name|guest}           值不存在或为空时使用默认值 guest
+//	{name|upper}           过滤器: upper、lower、title、trim、html、url、number[:小数位]、default:值
+//	{name|trim|upper|无名} 依次应用过滤器，非过滤器名视为默认值
+//	{{ 与 }}               输出字面量 { 与 }
+func (st StrType) CompileTemplate(tpl string) (*Template, error) {
+	t := &Template{src: tpl}
+	var text strings.Builder
+	for i := 0; i < len(tpl); i++ {
+		c := tpl[i]
+		switch {
+		case c == '{' && i+1 < len(tpl) && tpl[i+1] == '{':
+			text.WriteByte('{')
+			i++
+		case c == '}' && i+1 < len(tpl) && tpl[i+1] == '}':
+			text.WriteByte('}')
+			i++
+		case c == '}':
+			return nil, fmt.Errorf("gu.St.CompileTemplate() Error: unexpected '}' at %d", i)
+		case c == '{':
+			end := strings.IndexByte(tpl[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("gu.St.CompileTemplate() Error: unclosed '{' at %d", i)
+			}
+			seg, err := parsePlaceholder(tpl[i+1 : i+end])
+			if err != nil {
+				return nil, err
+			}
+			if text.Len() > 0 {
+				t.segments = append(t.segments, templateSegment{text: text.String()})
+				text.Reset()
+			}
+			t.segments = append(t.segments, seg)
+			i += end
+		default:
+			text.WriteByte(c)
+		}
+	}
+	if text.Len() > 0 {
+		t.segments = append(t.segments, templateSegment{text: text.String()})
+	}
+	return t, nil
+}
+
+func parsePlaceholder(s string) (templateSegment, error) {
+	parts := strings.Split(s, "|")
+	name := strings.TrimSpace(parts[0])
+	if name == "" {
+		return templateSegment{}, fmt.Errorf("gu.St.CompileTemplate() Error: empty placeholder {%s}", s)
+	}
+
+	seg := templateSegment{path: strings.Split(name, "."), holder: s}
+	for _, p := range seg.path {
+		if p == "" {
+			return templateSegment{}, fmt.Errorf("gu.St.CompileTemplate() Error: invalid name %q", name)
+		}
+	}
+	for _, part := range parts[1:] {
+		fname, arg := part, ""
+		if i := strings.IndexByte(part, ':'); i >= 0 {
+			fname, arg = part[:i], part[i+1:]
+		}
+		fname = strings.TrimSpace(fname)
+		fn, ok := templateFilter(fname)
+		if !ok {
+			// 非过滤器名视为默认值
+			fname, arg = "default", part
+			fn, _ = templateFilter(fname)
+		}
+		seg.filters = append(seg.filters, templateFilterCall{name: fname, arg: arg, fn: fn})
+		if fname == "default" {
+			seg.hasDef = true
+		}
+	}
+	return seg, nil
+}
+
+// 使用 data(map 或结构体)渲染模板，占位符的值不存在且没有默认值时返回错误
+func (t *Template) Execute(data any) (string, error) {
+	var b strings.Builder
+	root := reflect.ValueOf(data)
+	for _, seg := range t.segments {
+		if seg.path == nil {
+			b.WriteString(seg.text)
+			continue
+		}
+
+		value, ok := templateLookup(root, seg.path)
+		if !ok && !seg.hasDef {
+			return "", fmt.Errorf("gu.St.Template() Error: missing value for {%s}", seg.holder)
+		}
+		for _, call := range seg.filters {
+			var err error
+			if value, err = call.fn(value, call.arg); err != nil {
+				return "", fmt.Errorf("gu.St.Template() Error: filter %s in {%s}: %w", call.name, seg.holder, err)
+			}
+		}
+		b.WriteString(value)
+	}
+	return b.String(), nil
+}
+
+// 返回模板源文本
+func (t *Template) String() string {
+	return t.src
+}
+
+// 渲染模板，同 CompileTemplate 后 Execute，需多次渲染同一模板时使用 CompileTemplate
+func (st StrType) Template(tpl string, data any) (string, error) {
+	t, err := st.CompileTemplate(tpl)
+	if err != nil {
+		return "", err
+	}
+	return t.Execute(data)
+}
+
+// 按路径取值，nil 值视为空字符串
+func templateLookup(v reflect.Value, path []string) (string, bool) {
+	for _, key := range path {
+		for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+			v = v.Elem()
+		}
+		if !v.IsValid() {
+			return "", false
+		}
+
+		switch v.Kind() {
+		case reflect.Map:
+			if v.Type().Key().Kind() != reflect.String {
+				return "", false
+			}
+			v = v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key()))
+		case reflect.Struct:
+			v = templateField(v, key)
+		default:
+			return "", false
+		}
+		if !v.IsValid() {
+			return "", false
+		}
+	}
+
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", true
+		}
+		v = v.Elem()
+	}
+	return fmt.Sprint(v.Interface()), true
+}
+
+// 依次按字段名、json 标签、忽略大小写的字段名查找导出字段
+func templateField(v reflect.Value, key string) reflect.Value {
+	typ := v.Type()
+	if f, ok := typ.FieldByName(key); ok && f.IsExported() {
+		// 嵌入的结构体指针为 nil 时视为不存在
+		fv, _ := v.FieldByIndexErr(f.Index)
+		return fv
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if tag := strings.Split(f.Tag.Get("json"), ",")[0]; tag == key {
+			return v.Field(i)
+		}
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.IsExported() && strings.EqualFold(f.Name, key) {
+			return v.Field(i)
+		}
+	}
+	return reflect.Value{}
+}
@@ -0,0 +1,103 @@
+package types
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type tplUser struct {
+	Name    string
+	Email   string `json:"mail"`
+	Balance float64
+	Profile *tplProfile
+	secret  string
+}
+
+type tplProfile struct {
+	City string
+}
+
+func TestTemplate(t *testing.T) {
+	s, err := st.Template("Hello {name}, you have {count} msgs", map[string]any{"name": "Tom", "count": 3})
+	assert.Nil(t, err)
+	assert.Equal(t, "Hello Tom, you have 3 msgs", s)
+
+	// 默认值与过滤器
+	s, err = st.Template("Hi {name|guest}! {title|upper|none}", map[string]string{"title": "vip"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Hi guest! VIP", s)
+	s, _ = st.Template("{name|trim|默认}|{name|default:upper}", map[string]any{"name": "  ", "x": nil})
+	assert.Equal(t, "默认|  ", s)
+	s, _ = st.Template("{x|空}", map[string]any{"x": nil})
+	assert.Equal(t, "空", s)
+
+	// 数字格式化
+	s, err = st.Template("{a|number} {b|number:2} {c|number} {d|number:0}", map[string]any{
+		"a": 1234567, "b": 1234.5, "c": -9876543.21, "d": "999.6",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "1,234,567 1,234.50 -9,876,543.21 1,000", s)
+
+	// 转义
+	s, _ = st.Template("{{literal}} {v|html} {q|url}", map[string]any{"v": "<b>&</b>", "q": "a b&c"})
+	assert.Equal(t, "{literal} &lt;b&gt;&amp;&lt;/b&gt; a+b%26c", s)
+}
+
+func TestTemplateStruct(t *testing.T) {
+	u := &tplUser{Name: "张三", Email: "a@b.c", Balance: 1500, Profile: &tplProfile{City: "上海"}, secret: "x"}
+	s, err := st.Template("{Name} <{mail}> {balance|number:2} {profile.city}", u)
+	assert.Nil(t, err)
+	assert.Equal(t, "张三 <a@b.c> 1,500.00 上海", s)
+
+	// map 与结构体嵌套
+	s, err = st.Template("{user.name|title} from {user.profile.city}", map[string]any{
+		"user": tplUser{Name: "bob", Profile: &tplProfile{City: "Paris"}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "Bob from Paris", s)
+}
+
+func TestTemplateErrors(t *testing.T) {
+	u := tplUser{Name: "bob"}
+	_, err := st.Template("{secret}", u)
+	assert.NotNil(t, err)
+	_, err = st.Template("{profile.city}", u)
+	assert.NotNil(t, err)
+	s, err := st.Template("{profile.city|未知}", u)
+	assert.Nil(t, err)
+	assert.Equal(t, "未知", s)
+
+	_, err = st.Template("{missing}", nil)
+	assert.NotNil(t, err)
+	_, err = st.Template("{n|number}", map[string]any{"n": "abc"})
+	assert.NotNil(t, err)
+
+	for _, tpl := range []string{"{", "}", "{}", "{a..b}", "a {b"} {
+		_, err := st.CompileTemplate(tpl)
+		assert.NotNil(t, err, tpl)
+	}
+}
+
+func TestCompiledTemplate(t *testing.T) {
+	st.RegisterTemplateFilter("star", func(v, arg string) (string, error) {
+		return "*" + v + "*" + arg, nil
+	})
+	tpl, err := st.CompileTemplate("{id}:{name|star:!}")
+	assert.Nil(t, err)
+	assert.Equal(t, "{id}:{name|star:!}", tpl.String())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			s, err := tpl.Execute(map[string]any{"id": i, "name": "x"})
+			assert.Nil(t, err)
+			assert.True(t, strings.HasSuffix(s, ":*x*!"))
+		}(i)
+	}
+	wg.Wait()
+}
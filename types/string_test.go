@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/arnoluo/gu/random"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -29,6 +30,14 @@ func TestRandom(t *testing.T) {
 	assert.Len(t, b, 6)
 	assert.Len(t, c, 6)
 	assert.Equal(t, "ccc", d)
+	assert.Equal(t, 4, len([]rune(st.RandChars("中文", 4))))
+
+	// 固定种子时结果可复现
+	prev := random.SetDefault(random.New(random.NewSeeded(42)))
+	e := st.Rand(16)
+	random.SetDefault(random.New(random.NewSeeded(42)))
+	assert.Equal(t, e, st.Rand(16))
+	random.SetDefault(prev)
 }
 
 func TestRegReplace(t *testing.T) {
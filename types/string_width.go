@@ -0,0 +1,242 @@
+package types
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 东亚宽字符(W/F)与默认以 emoji 形式显示的字符，终端中占 2 列
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F}, {0x231A, 0x231B}, {0x2329, 0x232A}, {0x23E9, 0x23EC}, {0x23F0, 0x23F0},
+	{0x23F3, 0x23F3}, {0x25FD, 0x25FE}, {0x2614, 0x2615}, {0x2648, 0x2653}, {0x267F, 0x267F},
+	{0x2693, 0x2693}, {0x26A1, 0x26A1}, {0x26AA, 0x26AB}, {0x26BD, 0x26BE}, {0x26C4, 0x26C5},
+	{0x26CE, 0x26CE}, {0x26D4, 0x26D4}, {0x26EA, 0x26EA}, {0x26F2, 0x26F3}, {0x26F5, 0x26F5},
+	{0x26FA, 0x26FA}, {0x26FD, 0x26FD}, {0x2705, 0x2705}, {0x270A, 0x270B}, {0x2728, 0x2728},
+	{0x274C, 0x274C}, {0x274E, 0x274E}, {0x2753, 0x2755}, {0x2757, 0x2757}, {0x2795, 0x2797},
+	{0x27B0, 0x27B0}, {0x27BF, 0x27BF}, {0x2B1B, 0x2B1C}, {0x2B50, 0x2B50}, {0x2B55, 0x2B55},
+	{0x2E80, 0x303E}, {0x3041, 0x33FF}, {0x3400, 0x4DBF}, {0x4E00, 0x9FFF}, {0xA000, 0xA4CF},
+	{0xA960, 0xA97F}, {0xAC00, 0xD7A3}, {0xF900, 0xFAFF}, {0xFE10, 0xFE19}, {0xFE30, 0xFE6F},
+	{0xFF00, 0xFF60}, {0xFFE0, 0xFFE6}, {0x16FE0, 0x16FE4}, {0x17000, 0x18AFF}, {0x1B000, 0x1B2FF},
+	{0x1F004, 0x1F004}, {0x1F0CF, 0x1F0CF}, {0x1F18E, 0x1F18E}, {0x1F191, 0x1F19A}, {0x1F200, 0x1F251},
+	{0x1F300, 0x1F320}, {0x1F32D, 0x1F335}, {0x1F337, 0x1F37C}, {0x1F37E, 0x1F393}, {0x1F3A0, 0x1F3CA},
+	{0x1F3CF, 0x1F3D3}, {0x1F3E0, 0x1F3F0}, {0x1F3F4, 0x1F3F4}, {0x1F3F8, 0x1F43E}, {0x1F440, 0x1F440},
+	{0x1F442, 0x1F4FC}, {0x1F4FF, 0x1F53D}, {0x1F54B, 0x1F54E}, {0x1F550, 0x1F567}, {0x1F57A, 0x1F57A},
+	{0x1F595, 0x1F596}, {0x1F5A4, 0x1F5A4}, {0x1F5FB, 0x1F64F}, {0x1F680, 0x1F6C5}, {0x1F6CC, 0x1F6CC},
+	{0x1F6D0, 0x1F6D2}, {0x1F6D5, 0x1F6D7}, {0x1F6DC, 0x1F6DF}, {0x1F6EB, 0x1F6EC}, {0x1F6F4, 0x1F6FC},
+	{0x1F7E0, 0x1F7EB}, {0x1F7F0, 0x1F7F0}, {0x1F90C, 0x1F93A}, {0x1F93C, 0x1F945}, {0x1F947, 0x1F9FF},
+	{0x1FA70, 0x1FAFF}, {0x20000, 0x2FFFD}, {0x30000, 0x3FFFD},
+}
+
+func inRanges(r rune, ranges [][2]rune) bool {
+	i := sort.Search(len(ranges), func(i int) bool { return ranges[i][1] >= r })
+	return i < len(ranges) && ranges[i][0] <= r
+}
+
+// 单个字符的显示宽度: 控制字符与组合字符为 0，宽字符为 2，其余为 1
+func runeWidth(r rune) int {
+	switch {
+	case r < 0x20 || r == 0x7F:
+		return 0
+	case r < 0x300:
+		return 1
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf, unicode.Cc) || r >= 0x1160 && r <= 0x11FF:
+		return 0
+	case inRanges(r, wideRanges):
+		return 2
+	}
+	return 1
+}
+
+// 字素簇中附加到前一个字符上的字符: 组合字符、变体选择符、肤色修饰符、标签字符与韩文中声/终声
+func isGraphemeExtend(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) ||
+		r == 0x200C ||
+		r >= 0x1F3FB && r <= 0x1F3FF ||
+		r >= 0xE0020 && r <= 0xE007F ||
+		r >= 0x1160 && r <= 0x11FF
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// 返回 s 开头的字素簇(用户感知的单个字符)及其显示宽度
+//
+// 简化实现 UAX #29: CRLF、组合字符序列、emoji 修饰与 ZWJ 序列、国旗(区域指示符对)
+func nextGrapheme(s string) (string, int) {
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 {
+		return "", 0
+	}
+	if r == '\r' && size < len(s) && s[size] == '\n' {
+		return s[:size+1], 0
+	}
+	if r < 0x20 || r == 0x7F {
+		return s[:size], 0
+	}
+
+	width := runeWidth(r)
+	i := size
+	if isRegionalIndicator(r) {
+		if next, n := utf8.DecodeRuneInString(s[i:]); isRegionalIndicator(next) {
+			return s[:i+n], 2
+		}
+	}
+
+	for i < len(s) {
+		next, n := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case next == 0x200D:
+			// ZWJ 连接下一个字符
+			i += n
+			if after, m := utf8.DecodeRuneInString(s[i:]); m > 0 && after >= 0x20 {
+				i += m
+			}
+		case next == 0xFE0F:
+			// emoji 显示形式
+			width = 2
+			i += n
+		case isGraphemeExtend(next) || unicode.Is(unicode.Variation_Selector, next):
+			i += n
+		default:
+			return s[:i], width
+		}
+	}
+	return s[:i], width
+}
+
+// 返回字符串的显示宽度，中日韩文字与 emoji 占 2 列，组合字符占 0 列
+func (st StrType) Width(str string) int {
+	width := 0
+	for len(str) > 0 {
+		g, w := nextGrapheme(str)
+		width += w
+		str = str[len(g):]
+	}
+	return width
+}
+
+// 将字符串拆分为字素簇(用户感知的字符)，emoji ZWJ 序列、国旗、带组合字符的字母均视为一个字符
+func (st StrType) Graphemes(str string) []string {
+	var gs []string
+	for len(str) > 0 {
+		g, _ := nextGrapheme(str)
+		gs = append(gs, g)
+		str = str[len(g):]
+	}
+	return gs
+}
+
+// 返回字素簇个数
+func (st StrType) GraphemeLen(str string) int {
+	n := 0
+	for len(str) > 0 {
+		g, _ := nextGrapheme(str)
+		n++
+		str = str[len(g):]
+	}
+	return n
+}
+
+// 按字素簇截取子串，参数含义同 Sub
+func (st StrType) GraphemeSub(str string, begin, length int) string {
+	if begin < 0 {
+		begin = 0
+	}
+	if length <= 0 {
+		return ""
+	}
+
+	start := -1
+	for i, pos := 0, 0; pos < len(str); i++ {
+		if i == begin {
+			start = pos
+		}
+		if i == begin+length {
+			return str[start:pos]
+		}
+		g, _ := nextGrapheme(str[pos:])
+		pos += len(g)
+	}
+	if start < 0 {
+		return ""
+	}
+	return str[start:]
+}
+
+// 按显示宽度截断字符串，超出 width 时保留前缀并追加 ellipsis，结果宽度不超过 width
+//
+// ellipsis 宽度大于 width 时不追加
+func (st StrType) TruncateWidth(str string, width int, ellipsis string) string {
+	if st.Width(str) <= width {
+		return str
+	}
+	if width <= 0 {
+		return ""
+	}
+
+	limit := width
+	ew := st.Width(ellipsis)
+	if ew > width {
+		ellipsis = ""
+	} else {
+		limit -= ew
+	}
+
+	cur, pos := 0, 0
+	for pos < len(str) {
+		g, w := nextGrapheme(str[pos:])
+		if cur+w > limit {
+			break
+		}
+		cur += w
+		pos += len(g)
+	}
+	return str[:pos] + ellipsis
+}
+
+// 生成显示宽度为 width 的填充，pad 为空时使用空格，pad 无法恰好填满时剩余部分以空格补齐
+func (st StrType) padding(width int, pad string) string {
+	if width <= 0 {
+		return ""
+	}
+	if pad == "" {
+		pad = " "
+	}
+	gs := st.Graphemes(pad)
+
+	var b strings.Builder
+	cur := 0
+	for i := 0; cur < width; i++ {
+		g := gs[i%len(gs)]
+		w := st.Width(g)
+		if w <= 0 || cur+w > width {
+			break
+		}
+		b.WriteString(g)
+		cur += w
+	}
+	b.WriteString(strings.Repeat(" ", width-cur))
+	return b.String()
+}
+
+// 在左侧填充 pad 使显示宽度达到 width，已达到时原样返回
+func (st StrType) PadLeft(str string, width int, pad string) string {
+	return st.padding(width-st.Width(str), pad) + str
+}
+
+// 在右侧填充 pad 使显示宽度达到 width，已达到时原样返回
+func (st StrType) PadRight(str string, width int, pad string) string {
+	return str + st.padding(width-st.Width(str), pad)
+}
+
+// 在两侧填充 pad 使显示宽度达到 width 并居中，无法平分时右侧多填充一列
+func (st StrType) Center(str string, width int, pad string) string {
+	n := width - st.Width(str)
+	if n <= 0 {
+		return str
+	}
+	return st.padding(n/2, pad) + str + st.padding(n-n/2, pad)
+}
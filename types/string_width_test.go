@@ -0,0 +1,76 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	family = "👨‍👩‍👧" // ZWJ 序列
+	flag   = "🇨🇳"
+	thumbs = "👍🏽" // 肤色修饰
+	eAcute = "é"
+)
+
+func TestWidth(t *testing.T) {
+	cases := map[string]int{
+		"":                  0,
+		"abc":               3,
+		"中文":                4,
+		"ｱｲｳ":               3,
+		"ＡＢ":                4,
+		"한국어":               6,
+		"😀":                 2,
+		family:              2,
+		flag:                2,
+		thumbs:              2,
+		"❤️":                2,
+		"❤":                 1,
+		eAcute:              1,
+		"a\tb":              2,
+		"中a" + family + "b": 6,
+	}
+	for s, want := range cases {
+		assert.Equal(t, want, st.Width(s), s)
+	}
+}
+
+func TestGrapheme(t *testing.T) {
+	s := "a" + family + flag + eAcute + thumbs + "中\r\n"
+	assert.Equal(t, []string{"a", family, flag, eAcute, thumbs, "中", "\r\n"}, st.Graphemes(s))
+	assert.Equal(t, 7, st.GraphemeLen(s))
+	assert.Equal(t, 0, st.GraphemeLen(""))
+
+	assert.Equal(t, family+flag, st.GraphemeSub(s, 1, 2))
+	assert.Equal(t, thumbs+"中\r\n", st.GraphemeSub(s, 4, 10))
+	assert.Equal(t, "a", st.GraphemeSub(s, -1, 1))
+	assert.Equal(t, "", st.GraphemeSub(s, 7, 1))
+	assert.Equal(t, "", st.GraphemeSub(s, 0, 0))
+}
+
+func TestTruncateWidth(t *testing.T) {
+	assert.Equal(t, "hello", st.TruncateWidth("hello", 5, "..."))
+	assert.Equal(t, "he...", st.TruncateWidth("hello world", 5, "..."))
+	assert.Equal(t, "中文…", st.TruncateWidth("中文字符串", 5, "…"))
+	assert.Equal(t, "中…", st.TruncateWidth("中文字符串", 4, "…"))
+	// 不拆分 emoji 序列
+	assert.Equal(t, "a…", st.TruncateWidth("a"+family+"b", 3, "…"))
+	assert.Equal(t, "a"+family, st.TruncateWidth("a"+family+"bc", 3, ""))
+	assert.Equal(t, "...", st.TruncateWidth("中文", 3, "..."))
+	assert.Equal(t, "ab", st.TruncateWidth("abc", 2, "..."))
+	assert.Equal(t, "", st.TruncateWidth("中文", 1, "..."))
+	assert.Equal(t, "", st.TruncateWidth("中文", 0, "..."))
+}
+
+func TestPad(t *testing.T) {
+	assert.Equal(t, "  中文", st.PadLeft("中文", 6, ""))
+	assert.Equal(t, "中文  ", st.PadRight("中文", 6, " "))
+	assert.Equal(t, "中文", st.PadRight("中文", 3, " "))
+	assert.Equal(t, "00042", st.PadLeft("42", 5, "0"))
+	assert.Equal(t, "-=-=-ab", st.PadLeft("ab", 7, "-="))
+	assert.Equal(t, "ab中 ", st.PadRight("ab", 5, "中"))
+	assert.Equal(t, " 中文  ", st.Center("中文", 7, ""))
+	assert.Equal(t, "**"+flag+"**", st.Center(flag, 6, "*"))
+	assert.Equal(t, "abc", st.Center("abc", 2, ""))
+}
@@ -0,0 +1,128 @@
+package types
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 按显示宽度自动换行，原有换行符保留
+//
+// 中日韩文字可在任意字符间断行，拉丁单词保持完整，超过 width 的单词强制断开，断行处的空白被去除
+func (st StrType) Wrap(text string, width int) string {
+	return st.WrapIndent(text, width, "", "")
+}
+
+// 同 Wrap，每段首行以 first 开头，其余行以 rest 开头(悬挂缩进)，缩进计入 width
+func (st StrType) WrapIndent(text string, width int, first, rest string) string {
+	lines := strings.Split(text, "\n")
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		out = append(out, st.wrapLine(strings.TrimRight(line, "\r"), width, first, rest)...)
+	}
+	return strings.Join(out, "\n")
+}
+
+// 拆分为断行单元: 空白、拉丁单词、单个宽字符
+func wrapTokens(line string) []string {
+	var tokens []string
+	for len(line) > 0 {
+		r, _ := utf8.DecodeRuneInString(line)
+		end := 0
+		switch {
+		case unicode.IsSpace(r):
+			for end < len(line) {
+				r, n := utf8.DecodeRuneInString(line[end:])
+				if !unicode.IsSpace(r) {
+					break
+				}
+				end += n
+			}
+		default:
+			for end < len(line) {
+				r, _ := utf8.DecodeRuneInString(line[end:])
+				g, w := nextGrapheme(line[end:])
+				if unicode.IsSpace(r) || w == 2 && end > 0 {
+					break
+				}
+				end += len(g)
+				if w == 2 {
+					break
+				}
+			}
+		}
+		tokens = append(tokens, line[:end])
+		line = line[end:]
+	}
+	return tokens
+}
+
+func (st StrType) wrapLine(line string, width int, first, rest string) []string {
+	var lines []string
+	var cur strings.Builder
+	indent := first
+	cur.WriteString(indent)
+	curWidth := st.Width(indent)
+	pending := "" // 行内单词间的空白，断行时丢弃
+	empty := true
+
+	flush := func() {
+		lines = append(lines, cur.String())
+		cur.Reset()
+		indent = rest
+		cur.WriteString(indent)
+		curWidth = st.Width(indent)
+		pending = ""
+		empty = true
+	}
+
+	for _, tok := range wrapTokens(line) {
+		r, _ := utf8.DecodeRuneInString(tok)
+		if unicode.IsSpace(r) {
+			if !empty {
+				pending = tok
+			}
+			continue
+		}
+
+		w := st.Width(tok)
+		pw := st.Width(pending)
+		if !empty && curWidth+pw+w > width {
+			flush()
+			pw = 0
+		}
+		if !empty {
+			cur.WriteString(pending)
+			curWidth += pw
+		}
+		pending = ""
+
+		// 单词超过整行宽度时强制断开
+		for w > 0 && curWidth+w > width {
+			cut := st.TruncateWidth(tok, width-curWidth, "")
+			if cut == "" {
+				if !empty {
+					flush()
+					continue
+				}
+				// 宽度不足以容纳一个字符时至少放入一个字素簇
+				cut, _ = nextGrapheme(tok)
+			}
+			cur.WriteString(cut)
+			curWidth += st.Width(cut)
+			empty = false
+			tok = tok[len(cut):]
+			w = st.Width(tok)
+			if tok != "" {
+				flush()
+			}
+		}
+		if tok != "" {
+			cur.WriteString(tok)
+			curWidth += w
+			empty = false
+		}
+	}
+	lines = append(lines, strings.TrimRightFunc(cur.String(), unicode.IsSpace))
+	return lines
+}
@@ -0,0 +1,33 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrap(t *testing.T) {
+	assert.Equal(t, "The quick\nbrown fox\njumps over\nthe lazy\ndog", st.Wrap("The quick brown fox jumps over the lazy dog", 10))
+	// 中文可在任意字符间断行
+	assert.Equal(t, "中文自动\n换行测试", st.Wrap("中文自动换行测试", 8))
+	assert.Equal(t, "中文自动\n换行测试", st.Wrap("中文自动换行测试", 9))
+	assert.Equal(t, "使用 Go\n语言编写", st.Wrap("使用 Go 语言编写", 8))
+	// 超长单词强制断开
+	assert.Equal(t, "abcde\nfghij\nk", st.Wrap("abcdefghijk", 5))
+	assert.Equal(t, "see\nhttps://ex\nample.com", st.Wrap("see https://example.com", 10))
+	// 保留原有换行，断行处的空白被去除
+	assert.Equal(t, "aa bb\ncc\n\ndd", st.Wrap("aa bb   cc\n\ndd  ", 6))
+	assert.Equal(t, "中\n文", st.Wrap("中文", 1))
+	assert.Equal(t, "", st.Wrap("", 10))
+
+	for _, line := range strings.Split(st.Wrap("混合 mixed 文本 with emoji 😀😀😀 and 中文标点，。", 7), "\n") {
+		assert.True(t, st.Width(line) <= 7, line)
+	}
+}
+
+func TestWrapIndent(t *testing.T) {
+	text := "-v, --verbose  print detailed progress information"
+	assert.Equal(t, "  -v, --verbose  print\n      detailed progress\n      information", st.WrapIndent(text, 24, "  ", "      "))
+	assert.Equal(t, "1. 第一\n   项内\n   容", st.WrapIndent("第一项内容", 7, "1. ", "   "))
+}
@@ -0,0 +1,169 @@
+package types
+
+import (
+	"strings"
+	"unicode"
+)
+
+// 表格列对齐方式
+type Align int
+
+const (
+	AlignLeft Align = iota
+	AlignRight
+	AlignCenter
+)
+
+// 按显示宽度对齐的文本表格，适用于命令行输出
+type Table struct {
+	header    []string
+	rows      [][]string
+	aligns    map[int]Align
+	maxWidths map[int]int
+	border    bool
+}
+
+// 创建表格，header 为空时不输出表头，默认带边框
+func (st StrType) NewTable(header ...string) *Table {
+	return &Table{
+		header:    header,
+		aligns:    map[int]Align{},
+		maxWidths: map[int]int{},
+		border:    true,
+	}
+}
+
+// 添加一行
+func (t *Table) AddRow(cells ...string) *Table {
+	t.rows = append(t.rows, cells)
+	return t
+}
+
+// 设置第 col 列(从 0 开始)的对齐方式
+func (t *Table) SetAlign(col int, align Align) *Table {
+	t.aligns[col] = align
+	return t
+}
+
+// 设置第 col 列的最大显示宽度，超出时单元格内容自动换行
+func (t *Table) SetMaxWidth(col, width int) *Table {
+	t.maxWidths[col] = width
+	return t
+}
+
+// 设置是否输出边框，无边框时各列以两个空格分隔
+func (t *Table) SetBorder(border bool) *Table {
+	t.border = border
+	return t
+}
+
+// 渲染表格
+func (t *Table) String() string {
+	var st StrType
+	cols := len(t.header)
+	for _, row := range t.rows {
+		if len(row) > cols {
+			cols = len(row)
+		}
+	}
+	if cols == 0 {
+		return ""
+	}
+
+	widths := make([]int, cols)
+	// 将每个单元格按列宽换行后拆分为多行
+	cellLines := func(row []string) [][]string {
+		cells := make([][]string, cols)
+		for i := range cells {
+			text := ""
+			if i < len(row) {
+				text = row[i]
+			}
+			if mw, ok := t.maxWidths[i]; ok && mw > 0 {
+				text = st.Wrap(text, mw)
+			}
+			cells[i] = strings.Split(text, "\n")
+			for _, line := range cells[i] {
+				if w := st.Width(line); w > widths[i] {
+					widths[i] = w
+				}
+			}
+		}
+		return cells
+	}
+
+	var header [][]string
+	if len(t.header) > 0 {
+		header = cellLines(t.header)
+	}
+	rows := make([][][]string, len(t.rows))
+	for i, row := range t.rows {
+		rows[i] = cellLines(row)
+	}
+
+	var b strings.Builder
+	rule := func() {
+		parts := make([]string, cols)
+		for i, w := range widths {
+			if t.border {
+				parts[i] = strings.Repeat("-", w+2)
+			} else {
+				parts[i] = strings.Repeat("-", w)
+			}
+		}
+		if t.border {
+			b.WriteString("+" + strings.Join(parts, "+") + "+\n")
+		} else {
+			b.WriteString(strings.Join(parts, "  ") + "\n")
+		}
+	}
+	writeRow := func(cells [][]string) {
+		height := 0
+		for _, c := range cells {
+			if len(c) > height {
+				height = len(c)
+			}
+		}
+		for h := 0; h < height; h++ {
+			parts := make([]string, cols)
+			for i, c := range cells {
+				line := ""
+				if h < len(c) {
+					line = c[h]
+				}
+				parts[i] = alignCell(line, widths[i], t.aligns[i])
+			}
+			if t.border {
+				b.WriteString("| " + strings.Join(parts, " | ") + " |\n")
+			} else {
+				b.WriteString(strings.TrimRightFunc(strings.Join(parts, "  "), unicode.IsSpace) + "\n")
+			}
+		}
+	}
+
+	if t.border {
+		rule()
+	}
+	if header != nil {
+		writeRow(header)
+		rule()
+	}
+	for _, row := range rows {
+		writeRow(row)
+	}
+	if t.border && len(rows) > 0 {
+		rule()
+	}
+	return b.String()
+}
+
+func alignCell(text string, width int, align Align) string {
+	var st StrType
+	switch align {
+	case AlignRight:
+		return st.PadLeft(text, width, " ")
+	case AlignCenter:
+		return st.Center(text, width, " ")
+	}
+	return st.PadRight(text, width, " ")
+}
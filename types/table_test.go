@@ -0,0 +1,33 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable(t *testing.T) {
+	table := st.NewTable("名称", "Age", "备注").
+		AddRow("张三", "18", "").
+		AddRow("Bob", "7", "a long note here").
+		SetAlign(1, AlignRight).
+		SetMaxWidth(2, 8)
+	assert.Equal(t, strings.Join([]string{
+		"+------+-----+--------+",
+		"| 名称 | Age | 备注   |",
+		"+------+-----+--------+",
+		"| 张三 |  18 |        |",
+		"| Bob  |   7 | a long |",
+		"|      |     | note   |",
+		"|      |     | here   |",
+		"+------+-----+--------+",
+		"",
+	}, "\n"), table.String())
+
+	plain := st.NewTable("ID", "Name").AddRow("1", "中文").AddRow("20", "x").SetBorder(false).SetAlign(0, AlignCenter)
+	assert.Equal(t, "ID  Name\n--  ----\n1   中文\n20  x\n", plain.String())
+
+	assert.Equal(t, "+---+---+\n| a | b |\n+---+---+\n", st.NewTable().AddRow("a", "b").String())
+	assert.Equal(t, "", st.NewTable().String())
+}
@@ -0,0 +1,216 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	Ymd      string = "2006-01-02"
+	Ym       string = "2006-01"
+	His      string = "15:04:05"
+	YmdHis   string = "2006-01-02 15:04:05"
+	YmdHisMs string = "2006-01-02 15:04:05.000"
+)
+
+// 按 layout 格式化时间
+func (tt TimeType) Format(t time.Time, layout string) string {
+	return t.Format(layout)
+}
+
+// 转换到指定时区后按 layout 格式化时间
+func (tt TimeType) FormatIn(t time.Time, layout string, loc *time.Location) string {
+	return t.In(loc).Format(layout)
+}
+
+// 格式化为 2006-01-02
+func (tt TimeType) Ymd(t time.Time) string {
+	return t.Format(Ymd)
+}
+
+// 格式化为 2006-01
+func (tt TimeType) Ym(t time.Time) string {
+	return t.Format(Ym)
+}
+
+// 格式化为 15:04:05
+func (tt TimeType) His(t time.Time) string {
+	return t.Format(His)
+}
+
+// 格式化为 2006-01-02 15:04:05
+func (tt TimeType) YmdHis(t time.Time) string {
+	return t.Format(YmdHis)
+}
+
+// 格式化为 2006-01-02 15:04:05.000
+func (tt TimeType) YmdHisMs(t time.Time) string {
+	return t.Format(YmdHisMs)
+}
+
+// 按 layout 解析本地时区时间，同 time.ParseInLocation(layout, value, time.Local)
+func (tt TimeType) Parse(layout, value string) (time.Time, error) {
+	return time.ParseInLocation(layout, value, time.Local)
+}
+
+// 按 layout 解析指定时区时间
+func (tt TimeType) ParseIn(layout, value string, loc *time.Location) (time.Time, error) {
+	return time.ParseInLocation(layout, value, loc)
+}
+
+// 解析 2006-01-02 格式的本地时区时间
+func (tt TimeType) ParseYmd(value string) (time.Time, error) {
+	return tt.Parse(Ymd, value)
+}
+
+// 解析 2006-01-02 15:04:05 格式的本地时区时间
+func (tt TimeType) ParseYmdHis(value string) (time.Time, error) {
+	return tt.Parse(YmdHis, value)
+}
+
+// 将时间转换到指定时区，tz 为 IANA 时区名称，如 Asia/Shanghai
+func (tt TimeType) In(t time.Time, tz string) (time.Time, error) {
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return t, err
+	}
+	return t.In(loc), nil
+}
+
+// 返回 ISO 8601 周所在年份及周数
+func (tt TimeType) IsoWeek(t time.Time) (year, week int) {
+	return t.ISOWeek()
+}
+
+// 返回 ISO 8601 周字符串，如 2006-W01
+func (tt TimeType) IsoWeekStr(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%04d-W%02d", year, week)
+}
+
+// 返回 ISO 8601 周的第一天(周一)零点
+func (tt TimeType) IsoWeekStart(year, week int, loc *time.Location) time.Time {
+	// 1 月 4 日总在第一周内
+	jan4 := time.Date(year, 1, 4, 0, 0, 0, 0, loc)
+	return tt.StartOfWeek(jan4).AddDate(0, 0, (week-1)*7)
+}
+
+// 当天零点，以下 StartOf* / EndOf* 均使用 t 所在时区，跨时区请先 In()
+func (tt TimeType) StartOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// 当天最后一纳秒
+func (tt TimeType) EndOfDay(t time.Time) time.Time {
+	return tt.StartOfDay(t).AddDate(0, 0, 1).Add(-time.Nanosecond)
+}
+
+// 本周一零点(周一为一周的第一天)
+func (tt TimeType) StartOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return tt.StartOfDay(t).AddDate(0, 0, -offset)
+}
+
+// 本周日最后一纳秒
+func (tt TimeType) EndOfWeek(t time.Time) time.Time {
+	return tt.StartOfWeek(t).AddDate(0, 0, 7).Add(-time.Nanosecond)
+}
+
+// 本月第一天零点
+func (tt TimeType) StartOfMonth(t time.Time) time.Time {
+	y, m, _ := t.Date()
+	return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+}
+
+// 本月最后一纳秒
+func (tt TimeType) EndOfMonth(t time.Time) time.Time {
+	return tt.StartOfMonth(t).AddDate(0, 1, 0).Add(-time.Nanosecond)
+}
+
+// 本季度第一天零点
+func (tt TimeType) StartOfQuarter(t time.Time) time.Time {
+	y, m, _ := t.Date()
+	m = (m-1)/3*3 + 1
+	return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+}
+
+// 本季度最后一纳秒
+func (tt TimeType) EndOfQuarter(t time.Time) time.Time {
+	return tt.StartOfQuarter(t).AddDate(0, 3, 0).Add(-time.Nanosecond)
+}
+
+// 本年第一天零点
+func (tt TimeType) StartOfYear(t time.Time) time.Time {
+	return time.Date(t.Year(), 1, 1, 0, 0, 0, 0, t.Location())
+}
+
+// 本年最后一纳秒
+func (tt TimeType) EndOfYear(t time.Time) time.Time {
+	return tt.StartOfYear(t).AddDate(1, 0, 0).Add(-time.Nanosecond)
+}
+
+// 返回所在季度 1-4
+func (tt TimeType) Quarter(t time.Time) int {
+	return (int(t.Month())-1)/3 + 1
+}
+
+// 返回当月天数
+func (tt TimeType) DaysInMonth(t time.Time) int {
+	y, m, _ := t.Date()
+	return time.Date(y, m+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// 判断是否闰年
+func (tt TimeType) IsLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// 增加 n 个月，日期超出目标月天数时取目标月最后一天，如 01-31 加一个月为 02-28(29)
+//
+// 与 time.AddDate 不同，AddDate 会将 02-31 规范化为 03-03
+func (tt TimeType) AddMonths(t time.Time, n int) time.Time {
+	y, m, d := t.Date()
+	first := time.Date(y, m+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+	if last := tt.DaysInMonth(first); d > last {
+		d = last
+	}
+	return time.Date(first.Year(), first.Month(), d, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
+// 增加 n 年，闰年 02-29 在非闰年取 02-28
+func (tt TimeType) AddYears(t time.Time, n int) time.Time {
+	return tt.AddMonths(t, n*12)
+}
+
+// 返回 from 到 to 相差的自然日天数(to 早于 from 时为负数)，to 会先转换到 from 所在时区
+func (tt TimeType) DaysBetween(from, to time.Time) int {
+	y1, m1, d1 := from.Date()
+	y2, m2, d2 := to.In(from.Location()).Date()
+	a := time.Date(y1, m1, d1, 0, 0, 0, 0, time.UTC)
+	b := time.Date(y2, m2, d2, 0, 0, 0, 0, time.UTC)
+	// 按 Unix 秒计算，避免相差约 292 年以上时 time.Duration 溢出
+	return int((b.Unix() - a.Unix()) / 86400)
+}
+
+// 计算周岁，now 会先转换到 birth 所在时区；02-29 出生者在非闰年的 03-01 增加一岁
+func (tt TimeType) Age(birth, now time.Time) int {
+	now = now.In(birth.Location())
+	age := now.Year() - birth.Year()
+	if now.Month() < birth.Month() || (now.Month() == birth.Month() && now.Day() < birth.Day()) {
+		age--
+	}
+	if age < 0 {
+		return 0
+	}
+	return age
+}
+
+// If 根据条件判断返回不同的值。
+func (tt TimeType) If(isTrue bool, trueValue, falseValue time.Time) time.Time {
+	if isTrue {
+		return trueValue
+	}
+
+	return falseValue
+}
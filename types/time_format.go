@@ -0,0 +1,324 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 日期格式模式风格
+type TimePatternStyle int
+
+const (
+	// 自动识别：含 % 为 strftime，含 yy/MM/dd/HH/mm/ss 为 Java，否则为 PHP
+	PatternAuto TimePatternStyle = iota
+	// PHP date() 风格，如 Y-m-d H:i:s，\ 转义字面量
+	PatternPHP
+	// strftime 风格，如 %Y/%m/%d %H:%M:%S
+	PatternStrftime
+	// Java SimpleDateFormat 风格，如 yyyy-MM-dd HH:mm:ss，'' 包裹字面量
+	PatternJava
+)
+
+func (s TimePatternStyle) String() string {
+	switch s {
+	case PatternPHP:
+		return "php"
+	case PatternStrftime:
+		return "strftime"
+	case PatternJava:
+		return "java"
+	}
+	return "auto"
+}
+
+var phpTokens = map[byte]string{
+	'd': "02", 'D': "Mon", 'j': "2", 'l': "Monday",
+	'F': "January", 'm': "01", 'M': "Jan", 'n': "1",
+	'Y': "2006", 'y': "06",
+	'a': "pm", 'A': "PM", 'g': "3", 'h': "03", 'H': "15", 'i': "04", 's': "05",
+	'v': ".000", 'u': ".000000",
+	'T': "MST", 'O': "-0700", 'P': "-07:00", 'p': "Z07:00",
+	'c': "2006-01-02T15:04:05-07:00", 'r': "Mon, 02 Jan 2006 15:04:05 -0700",
+}
+
+var strftimeTokens = map[string]string{
+	"Y": "2006", "y": "06", "m": "01", "-m": "1", "d": "02", "-d": "2", "e": "_2",
+	"H": "15", "I": "03", "-I": "3", "M": "04", "S": "05", "p": "PM", "f": ".000000",
+	"b": "Jan", "h": "Jan", "B": "January", "a": "Mon", "A": "Monday",
+	"Z": "MST", "z": "-0700",
+	"F": "2006-01-02", "T": "15:04:05", "D": "01/02/06", "R": "15:04",
+}
+
+var javaTokens = map[string]string{
+	"yyyy": "2006", "yy": "06", "y": "2006",
+	"MMMM": "January", "MMM": "Jan", "MM": "01", "M": "1",
+	"dd": "02", "d": "2",
+	"HH": "15", "hh": "03", "h": "3",
+	"mm": "04", "m": "4", "ss": "05", "s": "5",
+	"SSS": ".000", "SSSSSS": ".000000", "SSSSSSSSS": ".000000000",
+	"a": "PM", "EEEE": "Monday", "EEE": "Mon", "EE": "Mon", "E": "Mon",
+	"z": "MST", "zzz": "MST", "Z": "-0700",
+	"XXX": "Z07:00", "XX": "Z0700", "X": "Z07",
+	"xxx": "-07:00", "xx": "-0700", "x": "-07",
+}
+
+// 模式翻译结果片段
+type layoutSegment struct {
+	text    string
+	literal bool
+}
+
+type layoutCacheKey struct {
+	style   TimePatternStyle
+	pattern string
+}
+
+type layoutCacheValue struct {
+	layout string
+	err    error
+}
+
+// 缓存的模式翻译结果个数
+const layoutCacheSize = 256
+
+// 模式 => Go layout 翻译缓存，限制容量以免模式来自外部输入时无限增长
+var layoutCache = newLRUCache[layoutCacheKey, layoutCacheValue](layoutCacheSize)
+
+// 将 PHP / strftime / Java 风格的日期模式翻译为 Go layout，结果会被缓存
+//
+// 无法在 Go layout 中无歧义表示的字面量(如数字、Jan、PM)将返回错误
+func (tt TimeType) Layout(pattern string, style TimePatternStyle) (string, error) {
+	if style == PatternAuto {
+		style = detectPatternStyle(pattern)
+	}
+
+	key := layoutCacheKey{style, pattern}
+	if cached, ok := layoutCache.get(key); ok {
+		return cached.layout, cached.err
+	}
+
+	var segs []layoutSegment
+	var err error
+	switch style {
+	case PatternPHP:
+		segs, err = translatePHP(pattern)
+	case PatternStrftime:
+		segs, err = translateStrftime(pattern)
+	case PatternJava:
+		segs, err = translateJava(pattern)
+	default:
+		err = fmt.Errorf("unknown pattern style %d", style)
+	}
+
+	var layout string
+	if err == nil {
+		layout, err = joinSegments(segs)
+	}
+	if err != nil {
+		err = fmt.Errorf("gu.Tt.Layout() Error: %s pattern %q: %s", style, pattern, err.Error())
+	}
+
+	layoutCache.add(key, layoutCacheValue{layout, err})
+	return layout, err
+}
+
+// 按日期模式格式化时间，style 为 PatternAuto 时自动识别风格
+func (tt TimeType) FormatPattern(t time.Time, pattern string, style TimePatternStyle) (string, error) {
+	layout, err := tt.Layout(pattern, style)
+	if err != nil {
+		return "", err
+	}
+	return t.Format(layout), nil
+}
+
+// 按日期模式解析指定时区时间，style 为 PatternAuto 时自动识别风格
+func (tt TimeType) ParsePattern(pattern, value string, style TimePatternStyle, loc *time.Location) (time.Time, error) {
+	layout, err := tt.Layout(pattern, style)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.ParseInLocation(layout, value, loc)
+}
+
+func detectPatternStyle(pattern string) TimePatternStyle {
+	if strings.Contains(pattern, "%") {
+		return PatternStrftime
+	}
+	for _, token := range []string{"yy", "MM", "dd", "HH", "hh", "mm", "ss"} {
+		if strings.Contains(pattern, token) {
+			return PatternJava
+		}
+	}
+	return PatternPHP
+}
+
+func translatePHP(pattern string) ([]layoutSegment, error) {
+	var segs []layoutSegment
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		if c == '\\' {
+			if i+1 < len(pattern) {
+				i++
+				segs = appendLiteral(segs, pattern[i:i+1])
+			}
+			continue
+		}
+
+		if layout, ok := phpTokens[c]; ok {
+			segs = append(segs, layoutSegment{text: layout})
+		} else if isASCIILetter(c) {
+			return nil, fmt.Errorf("unsupported token %q", c)
+		} else {
+			segs = appendLiteral(segs, pattern[i:i+1])
+		}
+	}
+	return segs, nil
+}
+
+func translateStrftime(pattern string) ([]layoutSegment, error) {
+	var segs []layoutSegment
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		if c != '%' {
+			segs = appendLiteral(segs, pattern[i:i+1])
+			continue
+		}
+
+		if i+1 >= len(pattern) {
+			return nil, fmt.Errorf("dangling %%")
+		}
+		i++
+		token := pattern[i : i+1]
+		if token == "-" && i+1 < len(pattern) {
+			i++
+			token = pattern[i-1 : i+1]
+		}
+
+		switch token {
+		case "%":
+			segs = appendLiteral(segs, "%")
+		case "n":
+			segs = appendLiteral(segs, "\n")
+		case "t":
+			segs = appendLiteral(segs, "\t")
+		default:
+			layout, ok := strftimeTokens[token]
+			if !ok {
+				return nil, fmt.Errorf("unsupported token %%%s", token)
+			}
+			segs = append(segs, layoutSegment{text: layout})
+		}
+	}
+	return segs, nil
+}
+
+func translateJava(pattern string) ([]layoutSegment, error) {
+	var segs []layoutSegment
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		switch {
+		case c == '\'':
+			// '' 表示单引号，'text' 表示字面量
+			if i+1 < len(pattern) && pattern[i+1] == '\'' {
+				segs = appendLiteral(segs, "'")
+				i += 2
+				continue
+			}
+			var sb strings.Builder
+			closed := false
+			for i++; i < len(pattern); i++ {
+				if pattern[i] != '\'' {
+					sb.WriteByte(pattern[i])
+				} else if i+1 < len(pattern) && pattern[i+1] == '\'' {
+					sb.WriteByte('\'')
+					i++
+				} else {
+					closed = true
+					i++
+					break
+				}
+			}
+			if !closed {
+				return nil, fmt.Errorf("unterminated quote")
+			}
+			segs = appendLiteral(segs, sb.String())
+		case isASCIILetter(c):
+			j := i
+			for j < len(pattern) && pattern[j] == c {
+				j++
+			}
+			token := pattern[i:j]
+			layout, ok := javaTokens[token]
+			if !ok && c == 'M' && len(token) > 4 {
+				layout, ok = "January", true
+			} else if !ok && c == 'E' && len(token) > 4 {
+				layout, ok = "Monday", true
+			}
+			if !ok {
+				return nil, fmt.Errorf("unsupported token %q", token)
+			}
+			segs = append(segs, layoutSegment{text: layout})
+			i = j
+		default:
+			segs = appendLiteral(segs, pattern[i:i+1])
+			i++
+		}
+	}
+	return segs, nil
+}
+
+func appendLiteral(segs []layoutSegment, text string) []layoutSegment {
+	if n := len(segs); n > 0 && segs[n-1].literal {
+		segs[n-1].text += text
+		return segs
+	}
+	return append(segs, layoutSegment{text: text, literal: true})
+}
+
+// 拼接片段为 Go layout，并校验拼接后的 layout 与逐段格式化的结果一致
+func joinSegments(segs []layoutSegment) (string, error) {
+	var sb strings.Builder
+	for i := range segs {
+		seg := &segs[i]
+		if !seg.literal && strings.HasPrefix(seg.text, ".0") {
+			// 小数秒在 Go layout 中需紧跟 "."，由模式中的 "." 字面量提供
+			if i == 0 || !segs[i-1].literal || !strings.HasSuffix(segs[i-1].text, ".") {
+				return "", fmt.Errorf("fractional seconds must follow a '.'")
+			}
+			prev := &segs[i-1]
+			prev.text = prev.text[:len(prev.text)-1]
+			s := sb.String()
+			sb.Reset()
+			sb.WriteString(s[:len(s)-1])
+		}
+		sb.WriteString(seg.text)
+	}
+	layout := sb.String()
+
+	for _, ref := range layoutCheckTimes {
+		var expect strings.Builder
+		for _, seg := range segs {
+			if seg.literal {
+				expect.WriteString(seg.text)
+			} else {
+				expect.WriteString(ref.Format(seg.text))
+			}
+		}
+		if ref.Format(layout) != expect.String() {
+			return "", fmt.Errorf("ambiguous literal in layout %q", layout)
+		}
+	}
+
+	return layout, nil
+}
+
+// 用于校验 layout 的参考时间，各字段互不相同且不与 layout 中的数字重合
+var layoutCheckTimes = []time.Time{
+	time.Date(2038, 11, 28, 19, 48, 37, 987654321, time.FixedZone("XYZ", -(9*3600+30*60))),
+	time.Date(1999, 8, 9, 8, 9, 9, 100000000, time.FixedZone("ABC", 11*3600)),
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,207 @@
+package types
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 2024-03-05 周二 07:08:09.123456789 +08:00
+var patternTime = time.Date(2024, 3, 5, 7, 8, 9, 123456789, time.FixedZone("CST", 8*3600))
+
+type patternCase struct {
+	pattern string
+	layout  string
+	output  string
+}
+
+func assertPatternCases(t *testing.T, style TimePatternStyle, base string, cases []patternCase) {
+	for _, c := range cases {
+		layout, err := tt.Layout(c.pattern, style)
+		assert.Nil(t, err, c.pattern)
+		assert.Equal(t, c.layout, layout, c.pattern)
+
+		out, err := tt.FormatPattern(patternTime, c.pattern, style)
+		assert.Nil(t, err, c.pattern)
+		assert.Equal(t, c.output, out, c.pattern)
+
+		// 与完整日期时间组合后可往返解析
+		full := base + " " + c.pattern
+		out, err = tt.FormatPattern(patternTime, full, style)
+		assert.Nil(t, err, full)
+		parsed, err := tt.ParsePattern(full, out, style, patternTime.Location())
+		assert.Nil(t, err, full)
+		again, _ := tt.FormatPattern(parsed, full, style)
+		assert.Equal(t, out, again, full)
+	}
+}
+
+func TestPHPPattern(t *testing.T) {
+	assertPatternCases(t, PatternPHP, "Y-m-d H:i:s", []patternCase{
+		{"d", "02", "05"},
+		{"D", "Mon", "Tue"},
+		{"j", "2", "5"},
+		{"l", "Monday", "Tuesday"},
+		{"F", "January", "March"},
+		{"m", "01", "03"},
+		{"M", "Jan", "Mar"},
+		{"n", "1", "3"},
+		{"Y", "2006", "2024"},
+		{"y", "06", "24"},
+		{"a", "pm", "am"},
+		{"A", "PM", "AM"},
+		{"g", "3", "7"},
+		{"h", "03", "07"},
+		{"H", "15", "07"},
+		{"i", "04", "08"},
+		{"s", "05", "09"},
+		{"s.v", "05.000", "09.123"},
+		{"s.u", "05.000000", "09.123456"},
+		{"T", "MST", "CST"},
+		{"O", "-0700", "+0800"},
+		{"P", "-07:00", "+08:00"},
+		{"p", "Z07:00", "+08:00"},
+		{"c", "2006-01-02T15:04:05-07:00", "2024-03-05T07:08:09+08:00"},
+		{"r", "Mon, 02 Jan 2006 15:04:05 -0700", "Tue, 05 Mar 2024 07:08:09 +0800"},
+		{`\T\o\d\a\y: Y`, "Today: 2006", "Today: 2024"},
+	})
+}
+
+func TestStrftimePattern(t *testing.T) {
+	assertPatternCases(t, PatternStrftime, "%F %T", []patternCase{
+		{"%Y", "2006", "2024"},
+		{"%y", "06", "24"},
+		{"%m", "01", "03"},
+		{"%-m", "1", "3"},
+		{"%d", "02", "05"},
+		{"%-d", "2", "5"},
+		{"%e", "_2", " 5"},
+		{"%H", "15", "07"},
+		{"%I", "03", "07"},
+		{"%-I", "3", "7"},
+		{"%M", "04", "08"},
+		{"%S", "05", "09"},
+		{"%S.%f", "05.000000", "09.123456"},
+		{"%p", "PM", "AM"},
+		{"%b", "Jan", "Mar"},
+		{"%h", "Jan", "Mar"},
+		{"%B", "January", "March"},
+		{"%a", "Mon", "Tue"},
+		{"%A", "Monday", "Tuesday"},
+		{"%Z", "MST", "CST"},
+		{"%z", "-0700", "+0800"},
+		{"%F", "2006-01-02", "2024-03-05"},
+		{"%T", "15:04:05", "07:08:09"},
+		{"%D", "01/02/06", "03/05/24"},
+		{"%R", "15:04", "07:08"},
+		{"%%%t%n", "%\t\n", "%\t\n"},
+	})
+}
+
+func TestJavaPattern(t *testing.T) {
+	assertPatternCases(t, PatternJava, "yyyy-MM-dd HH:mm:ss", []patternCase{
+		{"yyyy", "2006", "2024"},
+		{"yy", "06", "24"},
+		{"y", "2006", "2024"},
+		{"MMMMM", "January", "March"},
+		{"MMMM", "January", "March"},
+		{"MMM", "Jan", "Mar"},
+		{"MM", "01", "03"},
+		{"M", "1", "3"},
+		{"dd", "02", "05"},
+		{"d", "2", "5"},
+		{"HH", "15", "07"},
+		{"hh", "03", "07"},
+		{"h", "3", "7"},
+		{"mm", "04", "08"},
+		{"m", "4", "8"},
+		{"ss", "05", "09"},
+		{"s", "5", "9"},
+		{"ss.SSS", "05.000", "09.123"},
+		{"ss.SSSSSS", "05.000000", "09.123456"},
+		{"ss.SSSSSSSSS", "05.000000000", "09.123456789"},
+		{"a", "PM", "AM"},
+		{"EEEEE", "Monday", "Tuesday"},
+		{"EEEE", "Monday", "Tuesday"},
+		{"EEE", "Mon", "Tue"},
+		{"EE", "Mon", "Tue"},
+		{"E", "Mon", "Tue"},
+		{"z", "MST", "CST"},
+		{"zzz", "MST", "CST"},
+		{"Z", "-0700", "+0800"},
+		{"XXX", "Z07:00", "+08:00"},
+		{"XX", "Z0700", "+0800"},
+		{"X", "Z07", "+08"},
+		{"xxx", "-07:00", "+08:00"},
+		{"xx", "-0700", "+0800"},
+		{"x", "-07", "+08"},
+		{"'at' HH 'o''clock'", "at 15 o'clock", "at 07 o'clock"},
+		{"''", "'", "'"},
+	})
+}
+
+func TestPatternAuto(t *testing.T) {
+	for pattern, expect := range map[string]string{
+		"Y-m-d H:i:s":       "2024-03-05 07:08:09",
+		"%Y/%m/%d":          "2024/03/05",
+		"yyyy-MM-dd":        "2024-03-05",
+		"dd.MM.yyyy HH:mm":  "05.03.2024 07:08",
+		"Y年m月d日":            "2024年03月05日",
+		"yyyy'年'MM'月'dd'日'": "2024年03月05日",
+	} {
+		out, err := tt.FormatPattern(patternTime, pattern, PatternAuto)
+		assert.Nil(t, err, pattern)
+		assert.Equal(t, expect, out, pattern)
+	}
+
+	ti, err := tt.ParsePattern("Y-m-d H:i:s", "2024-03-05 07:08:09", PatternAuto, time.UTC)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 3, 5, 7, 8, 9, 0, time.UTC), ti)
+
+	assert.Equal(t, "auto", PatternAuto.String())
+	assert.Equal(t, "php", PatternPHP.String())
+	assert.Equal(t, "strftime", PatternStrftime.String())
+	assert.Equal(t, "java", PatternJava.String())
+}
+
+func TestPatternErrors(t *testing.T) {
+	for _, c := range []struct {
+		pattern string
+		style   TimePatternStyle
+	}{
+		{"Y-m-d U", PatternPHP},
+		{"Y-m-d 1", PatternPHP},
+		{`\J\a\n Y`, PatternPHP},
+		{"v", PatternPHP},
+		{"%Y %Q", PatternStrftime},
+		{"%Y %", PatternStrftime},
+		{"yyyy 'at", PatternJava},
+		{"yyyy GG", PatternJava},
+		{"yyyy '2'", PatternJava},
+		{"Y", TimePatternStyle(99)},
+	} {
+		_, err := tt.Layout(c.pattern, c.style)
+		assert.NotNil(t, err, c.pattern)
+
+		// 错误同样被缓存
+		_, err = tt.Layout(c.pattern, c.style)
+		assert.NotNil(t, err, c.pattern)
+
+		_, err = tt.FormatPattern(patternTime, c.pattern, c.style)
+		assert.NotNil(t, err, c.pattern)
+		_, err = tt.ParsePattern(c.pattern, "", c.style, time.UTC)
+		assert.NotNil(t, err, c.pattern)
+	}
+}
+
+func TestLayoutCache(t *testing.T) {
+	for i := 0; i < layoutCacheSize*2; i++ {
+		suffix := strings.Repeat("#", i)
+		layout, err := tt.Layout("Y-m-d "+suffix, PatternPHP)
+		assert.Nil(t, err)
+		assert.Equal(t, "2006-01-02 "+suffix, layout)
+	}
+	assert.Equal(t, layoutCacheSize, layoutCache.ll.Len())
+}
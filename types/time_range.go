@@ -0,0 +1,236 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// TimeRange 左闭右开的时间区间 [Start, End)
+type TimeRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// IsEmpty 判断区间是否为空(End 不晚于 Start)
+func (r TimeRange) IsEmpty() bool {
+	return !r.Start.Before(r.End)
+}
+
+// Duration 返回区间时长，空区间返回 0
+func (r TimeRange) Duration() time.Duration {
+	if r.IsEmpty() {
+		return 0
+	}
+	return r.End.Sub(r.Start)
+}
+
+// Contains 判断 t 是否在区间内
+func (r TimeRange) Contains(t time.Time) bool {
+	return !t.Before(r.Start) && t.Before(r.End)
+}
+
+// ContainsRange 判断 o 是否完全在区间内，空区间 o 在区间内当且仅当其起点在区间内
+func (r TimeRange) ContainsRange(o TimeRange) bool {
+	if o.IsEmpty() {
+		return r.Contains(o.Start)
+	}
+	return !o.Start.Before(r.Start) && !o.End.After(r.End)
+}
+
+// Overlaps 判断两个区间是否重叠，仅首尾相接不算重叠
+func (r TimeRange) Overlaps(o TimeRange) bool {
+	return !r.IsEmpty() && !o.IsEmpty() && r.Start.Before(o.End) && o.Start.Before(r.End)
+}
+
+// Intersect 返回两个区间的交集，不重叠时返回 false
+func (r TimeRange) Intersect(o TimeRange) (TimeRange, bool) {
+	if !r.Overlaps(o) {
+		return TimeRange{}, false
+	}
+	return TimeRange{laterTime(r.Start, o.Start), earlierTime(r.End, o.End)}, true
+}
+
+// Union 返回两个区间的并集，两个区间既不重叠也不相接时返回 false
+func (r TimeRange) Union(o TimeRange) (TimeRange, bool) {
+	switch {
+	case r.IsEmpty():
+		return o, !o.IsEmpty()
+	case o.IsEmpty():
+		return r, true
+	case r.Start.After(o.End) || o.Start.After(r.End):
+		return TimeRange{}, false
+	}
+	return TimeRange{earlierTime(r.Start, o.Start), laterTime(r.End, o.End)}, true
+}
+
+// Subtract 返回区间减去 o 后剩余的部分(0 到 2 个区间)
+func (r TimeRange) Subtract(o TimeRange) []TimeRange {
+	if r.IsEmpty() {
+		return nil
+	}
+	if !r.Overlaps(o) {
+		return []TimeRange{r}
+	}
+
+	var res []TimeRange
+	if r.Start.Before(o.Start) {
+		res = append(res, TimeRange{r.Start, o.Start})
+	}
+	if o.End.Before(r.End) {
+		res = append(res, TimeRange{o.End, r.End})
+	}
+	return res
+}
+
+// Split 从 Start 起按固定时长 d 切分区间，最后一段可能不足 d；d <= 0 时返回区间本身
+func (r TimeRange) Split(d time.Duration) []TimeRange {
+	if d <= 0 {
+		return r.splitBy(func(t time.Time) time.Time { return r.End })
+	}
+	return r.splitBy(func(t time.Time) time.Time { return t.Add(d) })
+}
+
+// SplitHours 按整点切分区间，使用 Start 所在时区
+func (r TimeRange) SplitHours() []TimeRange {
+	return r.splitBy(func(t time.Time) time.Time {
+		offset := time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+		return t.Add(time.Hour - offset)
+	})
+}
+
+// SplitDays 按自然日切分区间，使用 Start 所在时区
+func (r TimeRange) SplitDays() []TimeRange {
+	var tt TimeType
+	return r.splitBy(func(t time.Time) time.Time { return tt.StartOfDay(t).AddDate(0, 0, 1) })
+}
+
+// SplitMonths 按自然月切分区间，使用 Start 所在时区
+func (r TimeRange) SplitMonths() []TimeRange {
+	var tt TimeType
+	return r.splitBy(func(t time.Time) time.Time { return tt.StartOfMonth(t).AddDate(0, 1, 0) })
+}
+
+func (r TimeRange) splitBy(next func(t time.Time) time.Time) []TimeRange {
+	if r.IsEmpty() {
+		return nil
+	}
+	var res []TimeRange
+	for start := r.Start; start.Before(r.End); {
+		end := earlierTime(next(start), r.End)
+		res = append(res, TimeRange{start, end})
+		start = end
+	}
+	return res
+}
+
+// String 返回 [Start, End) 形式的字符串，时间使用 RFC3339 格式
+func (r TimeRange) String() string {
+	return "[" + r.Start.Format(time.RFC3339) + ", " + r.End.Format(time.RFC3339) + ")"
+}
+
+// 合并重叠或相接的区间，返回按 Start 排序的结果，空区间将被忽略
+func (tt TimeType) MergeRanges(ranges []TimeRange) []TimeRange {
+	sorted := make([]TimeRange, 0, len(ranges))
+	for _, r := range ranges {
+		if !r.IsEmpty() {
+			sorted = append(sorted, r)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+
+	var res []TimeRange
+	for _, r := range sorted {
+		if n := len(res); n > 0 && !r.Start.After(res[n-1].End) {
+			res[n-1].End = laterTime(res[n-1].End, r.End)
+			continue
+		}
+		res = append(res, r)
+	}
+	return res
+}
+
+func earlierTime(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+func laterTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+// DateIter 日期迭代器，按天或按月遍历两个日期之间(含首尾)的日期
+//
+//	it, err := gu.Tt.IterDays("2024-01-30", "2024-02-02")
+//	for it.Next() {
+//		fmt.Println(it.Ymd())
+//	}
+type DateIter struct {
+	cur     time.Time
+	end     time.Time
+	months  bool
+	started bool
+}
+
+// 返回按天遍历 from 到 to(含)的本地时区日期迭代器，from、to 为 2006-01-02 格式
+func (tt TimeType) IterDays(from, to string) (*DateIter, error) {
+	start, end, err := tt.parseIterRange(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("gu.Tt.IterDays() Error: %s", err.Error())
+	}
+	return &DateIter{cur: start, end: end}, nil
+}
+
+// 返回按月遍历 from 到 to(含)所在月份的本地时区迭代器，from、to 为 2006-01-02 格式，迭代值为每月第一天
+func (tt TimeType) IterMonths(from, to string) (*DateIter, error) {
+	start, end, err := tt.parseIterRange(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("gu.Tt.IterMonths() Error: %s", err.Error())
+	}
+	return &DateIter{cur: tt.StartOfMonth(start), end: tt.StartOfMonth(end), months: true}, nil
+}
+
+func (tt TimeType) parseIterRange(from, to string) (start, end time.Time, err error) {
+	if start, err = tt.ParseYmd(from); err != nil {
+		return
+	}
+	if end, err = tt.ParseYmd(to); err != nil {
+		return
+	}
+	if end.Before(start) {
+		err = fmt.Errorf("%s is before %s", to, from)
+	}
+	return
+}
+
+// Next 前进到下一个日期，遍历结束时返回 false
+func (it *DateIter) Next() bool {
+	if !it.started {
+		it.started = true
+	} else if it.months {
+		it.cur = it.cur.AddDate(0, 1, 0)
+	} else {
+		it.cur = it.cur.AddDate(0, 0, 1)
+	}
+	return !it.cur.After(it.end)
+}
+
+// Time 返回当前日期零点
+func (it *DateIter) Time() time.Time {
+	return it.cur
+}
+
+// Ymd 返回当前日期，如 2006-01-02
+func (it *DateIter) Ymd() string {
+	return it.cur.Format(Ymd)
+}
+
+// Ym 返回当前月份，如 2006-01
+func (it *DateIter) Ym() string {
+	return it.cur.Format(Ym)
+}
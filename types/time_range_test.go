@@ -0,0 +1,120 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func hm(d, h, m int) time.Time {
+	return time.Date(2024, 3, d, h, m, 0, 0, time.UTC)
+}
+
+func TestTimeRange(t *testing.T) {
+	r := TimeRange{hm(1, 9, 0), hm(1, 12, 0)}
+	assert.False(t, r.IsEmpty())
+	assert.True(t, TimeRange{hm(1, 9, 0), hm(1, 9, 0)}.IsEmpty())
+	assert.Equal(t, 3*time.Hour, r.Duration())
+	assert.Equal(t, time.Duration(0), TimeRange{hm(1, 12, 0), hm(1, 9, 0)}.Duration())
+
+	assert.True(t, r.Contains(hm(1, 9, 0)))
+	assert.True(t, r.Contains(hm(1, 11, 59)))
+	assert.False(t, r.Contains(hm(1, 12, 0)))
+	assert.True(t, r.ContainsRange(TimeRange{hm(1, 10, 0), hm(1, 12, 0)}))
+	assert.False(t, r.ContainsRange(TimeRange{hm(1, 10, 0), hm(1, 13, 0)}))
+
+	assert.True(t, r.Overlaps(TimeRange{hm(1, 11, 0), hm(1, 13, 0)}))
+	assert.False(t, r.Overlaps(TimeRange{hm(1, 12, 0), hm(1, 13, 0)}))
+	assert.False(t, r.Overlaps(TimeRange{hm(1, 10, 0), hm(1, 10, 0)}))
+
+	in, ok := r.Intersect(TimeRange{hm(1, 11, 0), hm(1, 13, 0)})
+	assert.True(t, ok)
+	assert.Equal(t, TimeRange{hm(1, 11, 0), hm(1, 12, 0)}, in)
+	_, ok = r.Intersect(TimeRange{hm(1, 12, 0), hm(1, 13, 0)})
+	assert.False(t, ok)
+
+	u, ok := r.Union(TimeRange{hm(1, 12, 0), hm(1, 13, 0)})
+	assert.True(t, ok)
+	assert.Equal(t, TimeRange{hm(1, 9, 0), hm(1, 13, 0)}, u)
+	_, ok = r.Union(TimeRange{hm(1, 13, 0), hm(1, 14, 0)})
+	assert.False(t, ok)
+
+	assert.Equal(t, []TimeRange{{hm(1, 9, 0), hm(1, 10, 0)}, {hm(1, 11, 0), hm(1, 12, 0)}}, r.Subtract(TimeRange{hm(1, 10, 0), hm(1, 11, 0)}))
+	assert.Equal(t, []TimeRange{{hm(1, 10, 0), hm(1, 12, 0)}}, r.Subtract(TimeRange{hm(1, 8, 0), hm(1, 10, 0)}))
+	assert.Equal(t, []TimeRange{r}, r.Subtract(TimeRange{hm(1, 12, 0), hm(1, 13, 0)}))
+	assert.Empty(t, r.Subtract(TimeRange{hm(1, 8, 0), hm(1, 13, 0)}))
+
+	assert.Equal(t, "[2024-03-01T09:00:00Z, 2024-03-01T12:00:00Z)", r.String())
+}
+
+func TestTimeRangeSplit(t *testing.T) {
+	r := TimeRange{hm(1, 9, 30), hm(1, 12, 0)}
+	assert.Equal(t, []TimeRange{
+		{hm(1, 9, 30), hm(1, 10, 30)}, {hm(1, 10, 30), hm(1, 11, 30)}, {hm(1, 11, 30), hm(1, 12, 0)},
+	}, r.Split(time.Hour))
+	assert.Equal(t, []TimeRange{r}, r.Split(0))
+	assert.Equal(t, []TimeRange{
+		{hm(1, 9, 30), hm(1, 10, 0)}, {hm(1, 10, 0), hm(1, 11, 0)}, {hm(1, 11, 0), hm(1, 12, 0)},
+	}, r.SplitHours())
+
+	days := TimeRange{hm(1, 18, 0), hm(3, 6, 0)}.SplitDays()
+	assert.Equal(t, []TimeRange{
+		{hm(1, 18, 0), hm(2, 0, 0)}, {hm(2, 0, 0), hm(3, 0, 0)}, {hm(3, 0, 0), hm(3, 6, 0)},
+	}, days)
+
+	months := TimeRange{hm(15, 0, 0), time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)}.SplitMonths()
+	assert.Equal(t, 2, len(months))
+	assert.Equal(t, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), months[0].End)
+
+	assert.Empty(t, TimeRange{}.Split(time.Hour))
+
+	// 夏令时当天只有 23 小时
+	ny, err := time.LoadLocation("America/New_York")
+	assert.Nil(t, err)
+	dst := TimeRange{time.Date(2024, 3, 10, 0, 0, 0, 0, ny), time.Date(2024, 3, 12, 0, 0, 0, 0, ny)}.SplitDays()
+	assert.Equal(t, 2, len(dst))
+	assert.Equal(t, 23*time.Hour, dst[0].Duration())
+	assert.Equal(t, 23, len(dst[0].SplitHours()))
+}
+
+func TestMergeRanges(t *testing.T) {
+	merged := tt.MergeRanges([]TimeRange{
+		{hm(1, 13, 0), hm(1, 14, 0)},
+		{hm(1, 9, 0), hm(1, 10, 0)},
+		{hm(1, 9, 30), hm(1, 11, 0)},
+		{hm(1, 11, 0), hm(1, 12, 0)},
+		{hm(1, 15, 0), hm(1, 15, 0)},
+		{hm(1, 13, 15), hm(1, 13, 45)},
+	})
+	assert.Equal(t, []TimeRange{{hm(1, 9, 0), hm(1, 12, 0)}, {hm(1, 13, 0), hm(1, 14, 0)}}, merged)
+	assert.Empty(t, tt.MergeRanges(nil))
+}
+
+func TestDateIter(t *testing.T) {
+	it, err := tt.IterDays("2024-02-27", "2024-03-01")
+	assert.Nil(t, err)
+	var days []string
+	for it.Next() {
+		days = append(days, it.Ymd())
+	}
+	assert.Equal(t, []string{"2024-02-27", "2024-02-28", "2024-02-29", "2024-03-01"}, days)
+
+	it, err = tt.IterMonths("2023-11-30", "2024-02-01")
+	assert.Nil(t, err)
+	var months []string
+	for it.Next() {
+		months = append(months, it.Ym())
+		assert.Equal(t, 1, it.Time().Day())
+	}
+	assert.Equal(t, []string{"2023-11", "2023-12", "2024-01", "2024-02"}, months)
+
+	it, _ = tt.IterDays("2024-01-01", "2024-01-01")
+	assert.True(t, it.Next())
+	assert.False(t, it.Next())
+
+	_, err = tt.IterDays("2024-01-02", "2024-01-01")
+	assert.NotNil(t, err)
+	_, err = tt.IterMonths("2024-01", "2024-02-01")
+	assert.NotNil(t, err)
+}
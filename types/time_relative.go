@@ -0,0 +1,386 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TimeLocale 相对时间语言包
+type TimeLocale struct {
+	// 时间差小于粒度时的文案，如 just now
+	Now string
+	// 过去与将来的格式，%s 为时长文案，如 "%s ago", "in %s"
+	Past   string
+	Future string
+	// 恰好相差一天时的文案，为空时使用 Past / Future
+	Yesterday string
+	Tomorrow  string
+	// 单位名称，依次为 年 月 周 天 小时 分钟 秒，每项为 [单数, 复数]
+	Units [7][2]string
+	// 数字与单位之间的分隔符
+	NumSep string
+	// 多个单位之间的分隔符
+	UnitSep string
+}
+
+// HumanizeOptions 相对时间格式化选项
+type HumanizeOptions struct {
+	// 语言，为空时使用默认语言(en)
+	Locale string
+	// 最小显示单位，时间差小于该值时显示 Now，默认 time.Second
+	Granularity time.Duration
+	// 最多显示的单位个数，默认 1
+	MaxUnits int
+}
+
+// 单位时长，年与月按 365 天与 30 天近似
+var relativeUnits = [7]time.Duration{
+	365 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	24 * time.Hour,
+	time.Hour,
+	time.Minute,
+	time.Second,
+}
+
+var (
+	timeLocaleMu      sync.RWMutex
+	defaultTimeLocale = "en"
+	timeLocales       = map[string]TimeLocale{
+		"en": {
+			Now:       "just now",
+			Past:      "%s ago",
+			Future:    "in %s",
+			Yesterday: "yesterday",
+			Tomorrow:  "tomorrow",
+			Units: [7][2]string{
+				{"year", "years"}, {"month", "months"}, {"week", "weeks"}, {"day", "days"},
+				{"hour", "hours"}, {"minute", "minutes"}, {"second", "seconds"},
+			},
+			NumSep:  " ",
+			UnitSep: " ",
+		},
+		"zh-CN": {
+			Now:       "刚刚",
+			Past:      "%s前",
+			Future:    "%s后",
+			Yesterday: "昨天",
+			Tomorrow:  "明天",
+			Units: [7][2]string{
+				{"年", "年"}, {"个月", "个月"}, {"周", "周"}, {"天", "天"},
+				{"小时", "小时"}, {"分钟", "分钟"}, {"秒", "秒"},
+			},
+		},
+	}
+)
+
+// 注册(或覆盖)相对时间语言包
+func (tt TimeType) RegisterLocale(name string, locale TimeLocale) {
+	timeLocaleMu.Lock()
+	defer timeLocaleMu.Unlock()
+	timeLocales[name] = locale
+}
+
+// 设置 Humanize 默认使用的语言，语言不存在时返回错误
+func (tt TimeType) SetDefaultLocale(name string) error {
+	timeLocaleMu.Lock()
+	defer timeLocaleMu.Unlock()
+	if _, ok := timeLocales[name]; !ok {
+		return fmt.Errorf("gu.Tt.SetDefaultLocale() Error: unknown locale %q", name)
+	}
+	defaultTimeLocale = name
+	return nil
+}
+
+func getTimeLocale(name string) TimeLocale {
+	timeLocaleMu.RLock()
+	defer timeLocaleMu.RUnlock()
+	if locale, ok := timeLocales[name]; ok {
+		return locale
+	}
+	return timeLocales[defaultTimeLocale]
+}
+
+// 返回 t 相对于 now 的描述，如 3 minutes ago, in 2 days
+func (tt TimeType) Humanize(t, now time.Time) string {
+	return tt.HumanizeWith(t, now, HumanizeOptions{})
+}
+
+// 按选项返回 t 相对于 now 的描述，如 1 day 2 hours ago, 3分钟前
+func (tt TimeType) HumanizeWith(t, now time.Time, opts HumanizeOptions) string {
+	if opts.Granularity <= 0 {
+		opts.Granularity = time.Second
+	}
+	if opts.MaxUnits <= 0 {
+		opts.MaxUnits = 1
+	}
+	locale := getTimeLocale(opts.Locale)
+
+	diff := t.Sub(now)
+	past := diff < 0
+	if past {
+		diff = -diff
+	}
+	if diff < opts.Granularity {
+		return locale.Now
+	}
+
+	var parts []string
+	var firstUnit time.Duration
+	var firstN time.Duration
+	for i, unit := range relativeUnits {
+		if unit < opts.Granularity && len(parts) > 0 {
+			break
+		}
+		n := diff / unit
+		if n == 0 {
+			continue
+		}
+		diff -= n * unit
+		if len(parts) == 0 {
+			firstUnit, firstN = unit, n
+		}
+
+		name := locale.Units[i][0]
+		if n > 1 {
+			name = locale.Units[i][1]
+		}
+		parts = append(parts, strconv.FormatInt(int64(n), 10)+locale.NumSep+name)
+		if len(parts) >= opts.MaxUnits {
+			break
+		}
+	}
+
+	// 仅显示一个单位且恰好为一天，并且按 now 所在时区为前一天或后一天
+	if len(parts) == 1 && firstUnit == 24*time.Hour && firstN == 1 {
+		days := tt.DaysBetween(now, t)
+		if days == -1 && locale.Yesterday != "" {
+			return locale.Yesterday
+		}
+		if days == 1 && locale.Tomorrow != "" {
+			return locale.Tomorrow
+		}
+	}
+
+	text := strings.Join(parts, locale.UnitSep)
+	if past {
+		return fmt.Sprintf(locale.Past, text)
+	}
+	return fmt.Sprintf(locale.Future, text)
+}
+
+var (
+	enRelativeRe = regexp.MustCompile(`^(?:in\s+)?(\d+|an?|one)\s*(s|secs?|seconds?|mins?|minutes?|h|hrs?|hours?|d|days?|w|weeks?|months?|y|yrs?|years?)(\s+ago|\s+later|\s+from\s+now)?$`)
+	enWeekdayRe  = regexp.MustCompile(`^(next|last|this)\s+(\w+)$`)
+	zhRelativeRe = regexp.MustCompile(`^([0-9零一二两三四五六七八九十百]+)\s*(秒钟|秒|分钟|分|个小时|小时|钟头|天|日|个星期|星期|周|个月|月|年)(前|以前|之前|后|以后|之后)$`)
+	zhWeekdayRe  = regexp.MustCompile(`^(上|下|本|这)(?:个)?(?:周|星期|礼拜)([一二三四五六日天])$`)
+)
+
+var enWeekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+var zhWeekdays = map[string]time.Weekday{
+	"一": time.Monday, "二": time.Tuesday, "三": time.Wednesday, "四": time.Thursday,
+	"五": time.Friday, "六": time.Saturday, "日": time.Sunday, "天": time.Sunday,
+}
+
+// 解析相对时间描述，如 2 hours ago, in 3 days, yesterday, next monday, 3分钟前, 昨天, 下周一
+//
+// today / yesterday / 周几 等以天为单位的描述返回当天零点
+func (tt TimeType) ParseRelative(str string, now time.Time) (time.Time, error) {
+	s := strings.ToLower(strings.Join(strings.Fields(str), " "))
+
+	switch s {
+	case "now", "just now", "刚刚", "现在":
+		return now, nil
+	case "today", "今天":
+		return tt.StartOfDay(now), nil
+	case "yesterday", "昨天":
+		return tt.StartOfDay(now).AddDate(0, 0, -1), nil
+	case "tomorrow", "明天":
+		return tt.StartOfDay(now).AddDate(0, 0, 1), nil
+	case "前天":
+		return tt.StartOfDay(now).AddDate(0, 0, -2), nil
+	case "后天":
+		return tt.StartOfDay(now).AddDate(0, 0, 2), nil
+	case "next week", "下周":
+		return now.AddDate(0, 0, 7), nil
+	case "last week", "上周":
+		return now.AddDate(0, 0, -7), nil
+	case "next month", "下个月":
+		return tt.AddMonths(now, 1), nil
+	case "last month", "上个月":
+		return tt.AddMonths(now, -1), nil
+	case "next year", "明年":
+		return tt.AddYears(now, 1), nil
+	case "last year", "去年":
+		return tt.AddYears(now, -1), nil
+	}
+
+	if m := enRelativeRe.FindStringSubmatch(s); m != nil {
+		ago := strings.TrimSpace(m[3]) == "ago"
+		if strings.HasPrefix(s, "in ") == (m[3] != "") {
+			// "in 2 days ago" 与 "2 days" 均无效
+			return now, fmt.Errorf("gu.Tt.ParseRelative() Error: unrecognized %q", str)
+		}
+		n := 1
+		if m[1] != "a" && m[1] != "an" && m[1] != "one" {
+			var err error
+			if n, err = strconv.Atoi(m[1]); err != nil {
+				return now, fmt.Errorf("gu.Tt.ParseRelative() Error: %s", err.Error())
+			}
+		}
+		if ago {
+			n = -n
+		}
+		return addRelative(now, n, enUnitIndex(m[2]))
+	}
+
+	if m := enWeekdayRe.FindStringSubmatch(s); m != nil {
+		if wd, ok := enWeekdays[m[2]]; ok {
+			today := tt.StartOfDay(now)
+			offset := int(wd) - int(now.Weekday())
+			switch m[1] {
+			case "next":
+				offset = (offset + 7) % 7
+				if offset == 0 {
+					offset = 7
+				}
+			case "last":
+				offset = (offset - 7) % 7
+				if offset == 0 {
+					offset = -7
+				}
+			case "this":
+				return tt.StartOfWeek(today).AddDate(0, 0, (int(wd)+6)%7), nil
+			}
+			return today.AddDate(0, 0, offset), nil
+		}
+	}
+
+	if m := zhRelativeRe.FindStringSubmatch(s); m != nil {
+		n, ok := parseZhNumber(m[1])
+		if ok {
+			if strings.HasSuffix(m[3], "前") {
+				n = -n
+			}
+			return addRelative(now, n, zhUnitIndex(m[2]))
+		}
+	}
+
+	if m := zhWeekdayRe.FindStringSubmatch(s); m != nil {
+		start := tt.StartOfWeek(now)
+		switch m[1] {
+		case "上":
+			start = start.AddDate(0, 0, -7)
+		case "下":
+			start = start.AddDate(0, 0, 7)
+		}
+		return start.AddDate(0, 0, (int(zhWeekdays[m[2]])+6)%7), nil
+	}
+
+	return now, fmt.Errorf("gu.Tt.ParseRelative() Error: unrecognized %q", str)
+}
+
+// 按单位下标(同 relativeUnits)增加 n 个单位，年月周日按日历计算，偏移超过 time.Duration 范围时返回错误
+func addRelative(t time.Time, n, unit int) (time.Time, error) {
+	if limit := int64(math.MaxInt64 / relativeUnits[unit]); int64(n) > limit || int64(n) < -limit {
+		return t, fmt.Errorf("gu.Tt.ParseRelative() Error: offset %d out of range", n)
+	}
+
+	var tt TimeType
+	switch unit {
+	case 0:
+		return tt.AddYears(t, n), nil
+	case 1:
+		return tt.AddMonths(t, n), nil
+	case 2:
+		return t.AddDate(0, 0, 7*n), nil
+	case 3:
+		return t.AddDate(0, 0, n), nil
+	}
+	return t.Add(time.Duration(n) * relativeUnits[unit]), nil
+}
+
+func enUnitIndex(unit string) int {
+	switch {
+	case strings.HasPrefix(unit, "y"):
+		return 0
+	case strings.HasPrefix(unit, "mo"):
+		return 1
+	case strings.HasPrefix(unit, "w"):
+		return 2
+	case strings.HasPrefix(unit, "d"):
+		return 3
+	case strings.HasPrefix(unit, "h"):
+		return 4
+	case strings.HasPrefix(unit, "m"):
+		return 5
+	}
+	return 6
+}
+
+func zhUnitIndex(unit string) int {
+	switch unit {
+	case "年":
+		return 0
+	case "个月", "月":
+		return 1
+	case "个星期", "星期", "周":
+		return 2
+	case "天", "日":
+		return 3
+	case "个小时", "小时", "钟头":
+		return 4
+	case "分钟", "分":
+		return 5
+	}
+	return 6
+}
+
+// 解析阿拉伯数字或 999 以内的中文数字
+func parseZhNumber(s string) (int, bool) {
+	if n, err := strconv.Atoi(s); err == nil {
+		return n, true
+	}
+
+	digits := map[rune]int{'零': 0, '一': 1, '二': 2, '两': 2, '三': 3, '四': 4, '五': 5, '六': 6, '七': 7, '八': 8, '九': 9}
+	total, cur := 0, -1
+	for _, r := range s {
+		switch r {
+		case '百', '十':
+			unit := 10
+			if r == '百' {
+				unit = 100
+			}
+			if cur < 0 {
+				cur = 1
+			}
+			total += cur * unit
+			cur = -1
+		default:
+			d, ok := digits[r]
+			if !ok {
+				return 0, false
+			}
+			cur = d
+		}
+	}
+	if cur > 0 {
+		total += cur
+	}
+	return total, true
+}
@@ -0,0 +1,132 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 2024-05-15 周三 10:30:00
+var relativeNow = time.Date(2024, 5, 15, 10, 30, 0, 0, time.UTC)
+
+func TestHumanize(t *testing.T) {
+	cases := []struct {
+		diff   time.Duration
+		opts   HumanizeOptions
+		expect string
+	}{
+		{0, HumanizeOptions{}, "just now"},
+		{-time.Second, HumanizeOptions{}, "1 second ago"},
+		{-3 * time.Minute, HumanizeOptions{}, "3 minutes ago"},
+		{-3*time.Minute - 20*time.Second, HumanizeOptions{MaxUnits: 2}, "3 minutes 20 seconds ago"},
+		{-30 * time.Second, HumanizeOptions{Granularity: time.Minute}, "just now"},
+		{-90 * time.Second, HumanizeOptions{Granularity: time.Minute, MaxUnits: 3}, "1 minute ago"},
+		{2 * 24 * time.Hour, HumanizeOptions{}, "in 2 days"},
+		{26 * time.Hour, HumanizeOptions{}, "tomorrow"},
+		{-26 * time.Hour, HumanizeOptions{}, "yesterday"},
+		{-26 * time.Hour, HumanizeOptions{MaxUnits: 2}, "1 day 2 hours ago"},
+		// 按日历日判断昨天与明天
+		{-34 * time.Hour, HumanizeOptions{}, "yesterday"},
+		{-35 * time.Hour, HumanizeOptions{}, "1 day ago"},
+		{-47 * time.Hour, HumanizeOptions{}, "1 day ago"},
+		{37 * time.Hour, HumanizeOptions{}, "tomorrow"},
+		{38 * time.Hour, HumanizeOptions{}, "in 1 day"},
+		{-14 * 24 * time.Hour, HumanizeOptions{}, "2 weeks ago"},
+		{-65 * 24 * time.Hour, HumanizeOptions{}, "2 months ago"},
+		{400 * 24 * time.Hour, HumanizeOptions{}, "in 1 year"},
+		{-3 * time.Minute, HumanizeOptions{Locale: "zh-CN"}, "3分钟前"},
+		{2 * 24 * time.Hour, HumanizeOptions{Locale: "zh-CN"}, "2天后"},
+		{-26 * time.Hour, HumanizeOptions{Locale: "zh-CN"}, "昨天"},
+		{-26 * time.Hour, HumanizeOptions{Locale: "zh-CN", MaxUnits: 2}, "1天2小时前"},
+		{0, HumanizeOptions{Locale: "zh-CN"}, "刚刚"},
+		{-3 * time.Minute, HumanizeOptions{Locale: "unknown"}, "3 minutes ago"},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.expect, tt.HumanizeWith(relativeNow.Add(c.diff), relativeNow, c.opts), c.diff)
+	}
+
+	assert.Equal(t, "5 hours ago", tt.Humanize(relativeNow.Add(-5*time.Hour), relativeNow))
+}
+
+func TestTimeLocale(t *testing.T) {
+	tt.RegisterLocale("test", TimeLocale{
+		Now: "now", Past: "-%s", Future: "+%s",
+		Units: [7][2]string{{"y", "y"}, {"mo", "mo"}, {"w", "w"}, {"d", "d"}, {"h", "h"}, {"m", "m"}, {"s", "s"}},
+	})
+	assert.Equal(t, "+1d", tt.HumanizeWith(relativeNow.Add(24*time.Hour), relativeNow, HumanizeOptions{Locale: "test"}))
+
+	assert.NotNil(t, tt.SetDefaultLocale("missing"))
+	assert.Nil(t, tt.SetDefaultLocale("zh-CN"))
+	defer tt.SetDefaultLocale("en")
+	assert.Equal(t, "5小时前", tt.Humanize(relativeNow.Add(-5*time.Hour), relativeNow))
+}
+
+func TestParseRelative(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, 5, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	cases := map[string]time.Time{
+		"now":             relativeNow,
+		"Just  Now":       relativeNow,
+		"today":           day(15),
+		"yesterday":       day(14),
+		"tomorrow":        day(16),
+		"2 hours ago":     relativeNow.Add(-2 * time.Hour),
+		"an hour ago":     relativeNow.Add(-time.Hour),
+		"in 3 days":       relativeNow.AddDate(0, 0, 3),
+		"5 minutes later": relativeNow.Add(5 * time.Minute),
+		"10s ago":         relativeNow.Add(-10 * time.Second),
+		"1 week from now": relativeNow.AddDate(0, 0, 7),
+		"in 1 month":      time.Date(2024, 6, 15, 10, 30, 0, 0, time.UTC),
+		"2 years ago":     time.Date(2022, 5, 15, 10, 30, 0, 0, time.UTC),
+		"next monday":     day(20),
+		"next wednesday":  day(22),
+		"last wednesday":  day(8),
+		"last fri":        day(10),
+		"this monday":     day(13),
+		"this sunday":     day(19),
+		"next week":       relativeNow.AddDate(0, 0, 7),
+		"last month":      time.Date(2024, 4, 15, 10, 30, 0, 0, time.UTC),
+		"next year":       time.Date(2025, 5, 15, 10, 30, 0, 0, time.UTC),
+		"刚刚":              relativeNow,
+		"今天":              day(15),
+		"昨天":              day(14),
+		"前天":              day(13),
+		"明天":              day(16),
+		"后天":              day(17),
+		"3分钟前":            relativeNow.Add(-3 * time.Minute),
+		"两小时后":            relativeNow.Add(2 * time.Hour),
+		"二十三秒前":           relativeNow.Add(-23 * time.Second),
+		"十天以后":            relativeNow.AddDate(0, 0, 10),
+		"1个月前":            time.Date(2024, 4, 15, 10, 30, 0, 0, time.UTC),
+		"下周一":             day(20),
+		"上周五":             day(10),
+		"本周日":             day(19),
+		"这个星期三":           day(15),
+		"去年":              time.Date(2023, 5, 15, 10, 30, 0, 0, time.UTC),
+	}
+
+	for str, expect := range cases {
+		ti, err := tt.ParseRelative(str, relativeNow)
+		assert.Nil(t, err, str)
+		assert.Equal(t, expect, ti, str)
+	}
+
+	for _, bad := range []string{"", "2 days", "in 2 days ago", "next funday", "三分钟", "几天前", "someday",
+		"99999999999999999999 days ago", "99999999999999999999天前",
+		"3000000 hours ago", "in 9999999999 seconds", "200000 days ago", "300 years ago"} {
+		_, err := tt.ParseRelative(bad, relativeNow)
+		assert.NotNil(t, err, bad)
+	}
+}
+
+func TestParseZhNumber(t *testing.T) {
+	for s, expect := range map[string]int{"0": 0, "12": 12, "十": 10, "十五": 15, "二十": 20, "九十九": 99, "一百零五": 105, "两": 2} {
+		n, ok := parseZhNumber(s)
+		assert.True(t, ok, s)
+		assert.Equal(t, expect, n, s)
+	}
+}
@@ -0,0 +1,132 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var tt TimeType
+
+func TestTimeFormat(t *testing.T) {
+	ti := time.Date(2024, 2, 29, 13, 4, 5, 123456789, time.UTC)
+	assert.Equal(t, "2024-02-29", tt.Ymd(ti))
+	assert.Equal(t, "2024-02", tt.Ym(ti))
+	assert.Equal(t, "13:04:05", tt.His(ti))
+	assert.Equal(t, "2024-02-29 13:04:05", tt.YmdHis(ti))
+	assert.Equal(t, "2024-02-29 13:04:05.123", tt.YmdHisMs(ti))
+	assert.Equal(t, "2024/02/29", tt.Format(ti, "2006/01/02"))
+
+	shanghai := time.FixedZone("CST", 8*3600)
+	assert.Equal(t, "2024-02-29 21:04:05", tt.FormatIn(ti, YmdHis, shanghai))
+}
+
+func TestTimeParse(t *testing.T) {
+	ti, err := tt.ParseYmd("2024-02-29")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 2, 29, 0, 0, 0, 0, time.Local), ti)
+
+	ti, err = tt.ParseYmdHis("2024-02-29 13:04:05")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 2, 29, 13, 4, 5, 0, time.Local), ti)
+
+	ti, err = tt.ParseIn(Ym, "2024-02", time.UTC)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), ti)
+
+	_, err = tt.Parse(Ymd, "2024-02-30")
+	assert.NotNil(t, err)
+
+	ti, err = tt.In(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), "Asia/Shanghai")
+	assert.Nil(t, err)
+	assert.Equal(t, "2024-01-01 08:00:00", tt.YmdHis(ti))
+
+	_, err = tt.In(ti, "Invalid/Zone")
+	assert.NotNil(t, err)
+}
+
+func TestIsoWeek(t *testing.T) {
+	// 2021-01-03 属于 2020 年第 53 周
+	ti := time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC)
+	year, week := tt.IsoWeek(ti)
+	assert.Equal(t, 2020, year)
+	assert.Equal(t, 53, week)
+	assert.Equal(t, "2020-W53", tt.IsoWeekStr(ti))
+
+	assert.Equal(t, time.Date(2020, 12, 28, 0, 0, 0, 0, time.UTC), tt.IsoWeekStart(2020, 53, time.UTC))
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), tt.IsoWeekStart(2024, 1, time.UTC))
+	assert.Equal(t, time.Date(2026, 12, 28, 0, 0, 0, 0, time.UTC), tt.IsoWeekStart(2026, 53, time.UTC))
+}
+
+func TestStartEndOf(t *testing.T) {
+	// 2024-05-15 周三
+	ti := time.Date(2024, 5, 15, 13, 4, 5, 6, time.UTC)
+	end := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 23, 59, 59, 999999999, time.UTC)
+	}
+
+	assert.Equal(t, time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC), tt.StartOfDay(ti))
+	assert.Equal(t, end(2024, 5, 15), tt.EndOfDay(ti))
+	assert.Equal(t, time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC), tt.StartOfWeek(ti))
+	assert.Equal(t, end(2024, 5, 19), tt.EndOfWeek(ti))
+	assert.Equal(t, time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC), tt.StartOfWeek(time.Date(2024, 5, 19, 1, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), tt.StartOfMonth(ti))
+	assert.Equal(t, end(2024, 5, 31), tt.EndOfMonth(ti))
+	assert.Equal(t, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), tt.StartOfQuarter(ti))
+	assert.Equal(t, end(2024, 6, 30), tt.EndOfQuarter(ti))
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), tt.StartOfYear(ti))
+	assert.Equal(t, end(2024, 12, 31), tt.EndOfYear(ti))
+	assert.Equal(t, 2, tt.Quarter(ti))
+	assert.Equal(t, 4, tt.Quarter(time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)))
+
+	// 使用 t 所在时区
+	shanghai := time.FixedZone("CST", 8*3600)
+	assert.Equal(t, time.Date(2024, 5, 15, 0, 0, 0, 0, shanghai), tt.StartOfDay(ti.In(shanghai)))
+}
+
+func TestAddMonths(t *testing.T) {
+	ti := time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC), tt.AddMonths(ti, 1))
+	assert.Equal(t, time.Date(2024, 4, 30, 10, 0, 0, 0, time.UTC), tt.AddMonths(ti, 3))
+	assert.Equal(t, time.Date(2023, 11, 30, 10, 0, 0, 0, time.UTC), tt.AddMonths(ti, -2))
+	assert.Equal(t, time.Date(2025, 1, 31, 10, 0, 0, 0, time.UTC), tt.AddMonths(ti, 12))
+	assert.Equal(t, time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC), tt.AddYears(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), 1))
+
+	assert.Equal(t, 29, tt.DaysInMonth(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 28, tt.DaysInMonth(time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, tt.IsLeapYear(2000))
+	assert.False(t, tt.IsLeapYear(1900))
+}
+
+func TestDaysBetween(t *testing.T) {
+	a := time.Date(2024, 3, 1, 23, 0, 0, 0, time.UTC)
+	b := time.Date(2024, 3, 2, 1, 0, 0, 0, time.UTC)
+	assert.Equal(t, 1, tt.DaysBetween(a, b))
+	assert.Equal(t, -1, tt.DaysBetween(b, a))
+	assert.Equal(t, 366, tt.DaysBetween(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)))
+	// 超出 time.Duration 范围
+	assert.Equal(t, 365242, tt.DaysBetween(time.Date(1000, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, -365242, tt.DaysBetween(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(1000, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	// 夏令时切换日不影响自然日天数
+	ny, err := time.LoadLocation("America/New_York")
+	if err == nil {
+		assert.Equal(t, 1, tt.DaysBetween(time.Date(2024, 3, 10, 0, 0, 0, 0, ny), time.Date(2024, 3, 11, 0, 0, 0, 0, ny)))
+	}
+}
+
+func TestAge(t *testing.T) {
+	birth := time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, 23, tt.Age(birth, time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 24, tt.Age(birth, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 22, tt.Age(birth, time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 23, tt.Age(birth, time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 0, tt.Age(birth, time.Date(1999, 1, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestTimeIf(t *testing.T) {
+	a, b := time.Unix(1, 0), time.Unix(2, 0)
+	assert.Equal(t, a, tt.If(true, a, b))
+	assert.Equal(t, b, tt.If(false, a, b))
+}
@@ -10,6 +10,7 @@ type (
 	BoolType  struct{}
 	FloatType struct{}
 	AnyType   struct{}
+	TimeType  struct{}
 	// ListType  struct{}
 	// RingType  struct{}
 	// HeapType  struct{}